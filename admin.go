@@ -0,0 +1,304 @@
+package schedule
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Admin is an http.Handler that exposes a JSON API for inspecting and
+// controlling the jobs of a running Scheduler. Paths are relative to the
+// handler, so it should be mounted with http.StripPrefix:
+//
+//	GET  /jobs               list all running jobs
+//	GET  /jobs/{id}          get a single job
+//	GET  /jobs/{id}/history  get the job's recent statuses
+//	POST /jobs/{id}/run      run the job now and return its status
+//	POST /jobs/{id}/pause    pause the job
+//	POST /jobs/{id}/resume   resume the job
+//	POST /jobs/{id}/schedule change the job's schedule, see scheduleJSON
+//	POST /jobs/{id}/quit     stop the job
+//
+// Every request is first passed to Authorize if it is set. Runs started by a
+// request are cancelled if the client disconnects.
+type Admin struct {
+	// Authorize is an optional hook that is called before every request.
+	// If it returns an error, the request is rejected as forbidden.
+	Authorize func(*http.Request) error
+
+	scheduler *Scheduler
+}
+
+// statusJSON is the JSON representation of a Status.
+type statusJSON struct {
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Duration float64   `json:"duration_ms"`
+	Wait     float64   `json:"wait_ms,omitempty"`
+	OK       bool      `json:"ok"`
+	Skipped  bool      `json:"skipped,omitempty"`
+	Error    string    `json:"error,omitempty"`
+	ExitCode int       `json:"exit_code,omitempty"`
+	Stdout   string    `json:"stdout,omitempty"`
+	Stderr   string    `json:"stderr,omitempty"`
+}
+
+func toStatusJSON(s Status) statusJSON {
+	out := statusJSON{
+		Start:    s.Start,
+		End:      s.End,
+		Duration: float64(s.End.Sub(s.Start)) / float64(time.Millisecond),
+		Wait:     float64(s.Wait) / float64(time.Millisecond),
+		OK:       s.Error == nil && !s.Skipped,
+		Skipped:  s.Skipped,
+		ExitCode: s.ExitCode,
+		Stdout:   s.Stdout,
+		Stderr:   s.Stderr,
+	}
+	if s.Error != nil {
+		out.Error = s.Error.Error()
+	}
+	return out
+}
+
+// statsJSON is the JSON representation of the Stats of a job's history.
+type statsJSON struct {
+	Runs                int     `json:"runs"`
+	Skipped             int     `json:"skipped"`
+	Failures            int     `json:"failures"`
+	SuccessRate         float64 `json:"success_rate"`
+	P50                 float64 `json:"p50_ms"`
+	P95                 float64 `json:"p95_ms"`
+	ConsecutiveFailures int     `json:"consecutive_failures"`
+}
+
+func toStatsJSON(s Stats) statsJSON {
+	return statsJSON{
+		Runs:                s.Runs,
+		Skipped:             s.Skipped,
+		Failures:            s.Failures,
+		SuccessRate:         s.SuccessRate,
+		P50:                 float64(s.P50) / float64(time.Millisecond),
+		P95:                 float64(s.P95) / float64(time.Millisecond),
+		ConsecutiveFailures: s.ConsecutiveFailures,
+	}
+}
+
+// jobJSON is the JSON representation of a Job.
+type jobJSON struct {
+	ID       int         `json:"id"`
+	Name     string      `json:"name"`
+	Schedule string      `json:"schedule"`
+	Running  bool        `json:"running"`
+	Paused   bool        `json:"paused"`
+	Next     *time.Time  `json:"next,omitempty"`
+	Last     *statusJSON `json:"last,omitempty"`
+	Stats    *statsJSON  `json:"stats,omitempty"`
+}
+
+func toJobJSON(j *Job) jobJSON {
+	out := jobJSON{
+		ID:       j.ID(),
+		Name:     j.Name,
+		Schedule: j.description(),
+		Running:  j.Running(),
+		Paused:   j.Paused(),
+	}
+	if next := j.Next(); !next.IsZero() {
+		out.Next = &next
+	}
+	if history := j.History(); len(history) > 0 {
+		last := toStatusJSON(history[len(history)-1])
+		stats := toStatsJSON(Summarize(history))
+		out.Last = &last
+		out.Stats = &stats
+	}
+	return out
+}
+
+// scheduleJSON is the JSON request body used to change a job's schedule.
+// Either an interval such as "5m" or a list of daily clocks such as
+// "17:00:00" must be given. Clocks are in the given location, or UTC.
+type scheduleJSON struct {
+	Every    string   `json:"every"`
+	Daily    []string `json:"daily"`
+	Location string   `json:"location"`
+}
+
+// schedule parses the request body into a Schedule.
+func (sj scheduleJSON) schedule() (Schedule, error) {
+	if sj.Every != "" && len(sj.Daily) > 0 {
+		return nil, errors.New("only one of every or daily may be given")
+	}
+	if sj.Every != "" {
+		d, err := time.ParseDuration(sj.Every)
+		if err != nil {
+			return nil, err
+		}
+		if d <= 0 {
+			return nil, errors.New("every must be positive")
+		}
+		return Interval(d), nil
+	}
+	if len(sj.Daily) == 0 {
+		return nil, errors.New("either every or daily must be given")
+	}
+	loc := time.UTC
+	if sj.Location != "" {
+		var err error
+		if loc, err = time.LoadLocation(sj.Location); err != nil {
+			return nil, err
+		}
+	}
+	clocks := make(Clocks, len(sj.Daily))
+	for i, value := range sj.Daily {
+		clock, err := ParseClockIn(value, loc)
+		if err != nil {
+			return nil, err
+		}
+		clocks[i] = clock
+	}
+	SortClocks(clocks)
+	return clocks, nil
+}
+
+// errNotFound is returned for unknown jobs and paths.
+var errNotFound = errors.New("not found")
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, map[string]string{"error": err.Error()})
+}
+
+// ServeHTTP routes the request to the matching endpoint.
+func (a *Admin) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if a.Authorize != nil {
+		if err := a.Authorize(r); err != nil {
+			writeError(w, http.StatusForbidden, err)
+			return
+		}
+	}
+
+	// Split the path into its components: jobs, the job id, and an action
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if parts[0] != "jobs" || len(parts) > 3 {
+		writeError(w, http.StatusNotFound, errNotFound)
+		return
+	}
+	if len(parts) == 1 {
+		a.list(w, r)
+		return
+	}
+
+	id, err := strconv.Atoi(parts[1])
+	if err != nil {
+		writeError(w, http.StatusNotFound, errNotFound)
+		return
+	}
+	job := a.scheduler.Job(id)
+	if job == nil {
+		writeError(w, http.StatusNotFound, errNotFound)
+		return
+	}
+
+	var action string
+	if len(parts) == 3 {
+		action = parts[2]
+	}
+	a.action(w, r, job, action)
+}
+
+// list writes every job on the scheduler.
+func (a *Admin) list(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+	jobs := a.scheduler.Jobs()
+	out := make([]jobJSON, len(jobs))
+	for i, job := range jobs {
+		out[i] = toJobJSON(job)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// actionMethods maps every job action to its allowed HTTP method.
+var actionMethods = map[string]string{
+	"":         "GET",
+	"history":  "GET",
+	"run":      "POST",
+	"pause":    "POST",
+	"resume":   "POST",
+	"schedule": "POST",
+	"quit":     "POST",
+}
+
+// action performs the given action on a single job.
+func (a *Admin) action(w http.ResponseWriter, r *http.Request, job *Job, action string) {
+	method, ok := actionMethods[action]
+	if !ok {
+		writeError(w, http.StatusNotFound, errNotFound)
+		return
+	}
+	if r.Method != method {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+
+	switch action {
+	case "":
+		writeJSON(w, http.StatusOK, toJobJSON(job))
+	case "history":
+		history := job.History()
+		out := make([]statusJSON, len(history))
+		for i, status := range history {
+			out[i] = toStatusJSON(status)
+		}
+		writeJSON(w, http.StatusOK, out)
+	case "run":
+		status, err := job.TriggerCtx(r.Context())
+		if err != nil {
+			writeError(w, http.StatusConflict, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, toStatusJSON(status))
+	case "pause":
+		job.Pause()
+		writeJSON(w, http.StatusOK, toJobJSON(job))
+	case "resume":
+		job.Resume()
+		writeJSON(w, http.StatusOK, toJobJSON(job))
+	case "schedule":
+		var body scheduleJSON
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		schedule, err := body.schedule()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		if _, err := job.Reschedule(schedule); err != nil {
+			writeError(w, http.StatusConflict, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, toJobJSON(job))
+	case "quit":
+		job.Quit()
+		writeJSON(w, http.StatusOK, toJobJSON(job))
+	}
+}
+
+// NewAdmin creates an Admin handler for the given Scheduler.
+func NewAdmin(s *Scheduler) *Admin {
+	return &Admin{scheduler: s}
+}
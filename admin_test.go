@@ -0,0 +1,160 @@
+package schedule
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// adminRequest performs a request against the handler and decodes the JSON
+// response into v.
+func adminRequest(t *testing.T, h http.Handler, method, path string, v interface{}) int {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	if v != nil {
+		if err := json.NewDecoder(w.Body).Decode(v); err != nil {
+			t.Fatalf("Could not decode response of %s %s: %s", method, path, err)
+		}
+	}
+	return w.Code
+}
+
+func TestAdmin(t *testing.T) {
+	s := New()
+	var count int
+	j := s.Whenever(func() error {
+		count += 1
+		if count == 2 {
+			return errors.New("failed")
+		}
+		return nil
+	}, make(chan time.Time))
+	admin := NewAdmin(s)
+	path := "/jobs/" + strconv.Itoa(j.ID())
+
+	var jobs []jobJSON
+	expectInt(t, adminRequest(t, admin, "GET", "/jobs", &jobs), 200)
+	if len(jobs) != 1 {
+		t.Fatalf("Unexpected number of jobs: %d != 1", len(jobs))
+	}
+	expectInt(t, jobs[0].ID, j.ID())
+	if !jobs[0].Running || jobs[0].Last != nil {
+		t.Errorf("Unexpected job: %+v", jobs[0])
+	}
+
+	// Trigger the job twice
+	var status statusJSON
+	expectInt(t, adminRequest(t, admin, "POST", path+"/run", &status), 200)
+	if !status.OK {
+		t.Errorf("The first run should succeed: %+v", status)
+	}
+	expectInt(t, adminRequest(t, admin, "POST", path+"/run", &status), 200)
+	expectString(t, status.Error, "failed")
+
+	var history []statusJSON
+	expectInt(t, adminRequest(t, admin, "GET", path+"/history", &history), 200)
+	expectInt(t, len(history), 2)
+
+	var job jobJSON
+	expectInt(t, adminRequest(t, admin, "GET", path, &job), 200)
+	if job.Last == nil || job.Last.Error != "failed" {
+		t.Errorf("Unexpected last status: %+v", job.Last)
+	}
+	if job.Stats == nil || job.Stats.Runs != 2 || job.Stats.ConsecutiveFailures != 1 {
+		t.Errorf("Unexpected stats: %+v", job.Stats)
+	}
+
+	// Pause and resume the job
+	expectInt(t, adminRequest(t, admin, "POST", path+"/pause", &job), 200)
+	if !job.Paused {
+		t.Error("The job should be paused")
+	}
+	expectInt(t, adminRequest(t, admin, "POST", path+"/resume", &job), 200)
+	if job.Paused {
+		t.Error("The job should not be paused")
+	}
+
+	// Change the job's schedule
+	w := httptest.NewRecorder()
+	admin.ServeHTTP(w, httptest.NewRequest("POST", path+"/schedule", strings.NewReader(`{"every": "1h"}`)))
+	expectInt(t, w.Code, 200)
+	if err := json.NewDecoder(w.Body).Decode(&job); err != nil {
+		t.Fatal(err)
+	}
+	expectString(t, job.Schedule, "every hour")
+	if job.Next == nil {
+		t.Error("The rescheduled job should have a next run")
+	}
+	w = httptest.NewRecorder()
+	admin.ServeHTTP(w, httptest.NewRequest("POST", path+"/schedule", strings.NewReader(`{}`)))
+	expectInt(t, w.Code, 400)
+
+	// Bad requests
+	expectInt(t, adminRequest(t, admin, "GET", path+"/run", nil), 405)
+	expectInt(t, adminRequest(t, admin, "GET", path+"/unknown", nil), 404)
+	expectInt(t, adminRequest(t, admin, "GET", "/jobs/1000", nil), 404)
+	expectInt(t, adminRequest(t, admin, "GET", "/other", nil), 404)
+
+	// Quit the job, which removes it from the scheduler
+	expectInt(t, adminRequest(t, admin, "POST", path+"/quit", &job), 200)
+	if job.Running {
+		t.Error("The job should no longer be running")
+	}
+	s.WaitForJobsToFinish()
+	expectInt(t, adminRequest(t, admin, "GET", "/jobs", &jobs), 200)
+	expectInt(t, len(jobs), 0)
+	if _, err := j.Trigger(); err != ErrJobStopped {
+		t.Errorf("Unexpected error triggering a stopped job: %v", err)
+	}
+}
+
+func TestAdmin_Authorize(t *testing.T) {
+	admin := NewAdmin(New())
+	admin.Authorize = func(r *http.Request) error {
+		if r.Header.Get("Authorization") != "secret" {
+			return errors.New("unauthorized")
+		}
+		return nil
+	}
+	expectInt(t, adminRequest(t, admin, "GET", "/jobs", nil), 403)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/jobs", nil)
+	r.Header.Set("Authorization", "secret")
+	admin.ServeHTTP(w, r)
+	expectInt(t, w.Code, 200)
+}
+
+func TestScheduleJSON(t *testing.T) {
+	schedule, err := scheduleJSON{Every: "5m"}.schedule()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schedule != Interval(5*time.Minute) {
+		t.Errorf("Unexpected schedule: %v", schedule)
+	}
+
+	schedule, err = scheduleJSON{Daily: []string{"17:00:00", "9:00:00"}}.schedule()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectString(t, Describe(schedule), "every day at 9:00 and 17:00 UTC")
+
+	invalid := []scheduleJSON{
+		{},
+		{Every: "-1m"},
+		{Every: "5m", Daily: []string{"9:00:00"}},
+		{Daily: []string{"9am"}},
+		{Daily: []string{"9:00:00"}, Location: "Nowhere/Nothing"},
+	}
+	for _, sj := range invalid {
+		if _, err := sj.schedule(); err == nil {
+			t.Errorf("Expected an error for schedule %+v", sj)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package schedule
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// NotificationKind is the reason a Notification was sent.
+type NotificationKind int
+
+const (
+	// NotifyFailing is sent when a job meets the conditions of its
+	// AlertPolicy, and again if it is still failing after the policy's
+	// repeat interval.
+	NotifyFailing NotificationKind = iota
+
+	// NotifyRecovered is sent when a failing job succeeds and no longer
+	// meets the conditions of its AlertPolicy.
+	NotifyRecovered
+)
+
+// String returns the name of the kind, such as "failing".
+func (k NotificationKind) String() string {
+	if k == NotifyRecovered {
+		return "recovered"
+	}
+	return "failing"
+}
+
+// Notification is sent to Notifiers when a job starts failing or recovers.
+// Reason describes the condition that was met, and Status is the run that
+// caused the notification. Suppressed is the number of notifications that
+// were suppressed since the last one was sent.
+type Notification struct {
+	Job        string
+	Kind       NotificationKind
+	Reason     string
+	Status     Status
+	Time       time.Time
+	Suppressed int
+}
+
+// String returns a description such as "backup is failing: 3 consecutive
+// failures (last error: exit status 1)".
+func (n Notification) String() string {
+	if n.Kind == NotifyRecovered {
+		return fmt.Sprintf("%s recovered", n.Job)
+	}
+	desc := fmt.Sprintf("%s is failing: %s", n.Job, n.Reason)
+	if n.Status.Error != nil {
+		desc = fmt.Sprintf("%s (last error: %s)", desc, n.Status.Error)
+	}
+	return desc
+}
+
+// Notifier delivers notifications, such as by email or to a webhook.
+// Notifiers are called after the run that caused the notification, from the
+// job's iteration loop, so the job does not run again until they return.
+type Notifier interface {
+	Notify(Notification) error
+}
+
+// AlertPolicy determines when the failures of a job are notified. A job is
+// failing once either of its conditions is met, and recovers once neither
+// is met and its last run succeeded. Skipped runs are ignored.
+type AlertPolicy struct {
+	// ConsecutiveFailures is the number of failures in a row that are
+	// notified. Zero disables the condition.
+	ConsecutiveFailures int
+
+	// FailureRate is the fraction of failed runs, between 0 and 1, within
+	// the last Window that is notified once it is exceeded. At least MinRuns
+	// runs must be in the window. The condition is disabled unless both the
+	// rate and the window are set.
+	FailureRate float64
+	Window      time.Duration
+	MinRuns     int
+
+	// Repeat is how long repeated notifications of a failing job are
+	// suppressed. Zero suppresses them until the job recovers.
+	Repeat time.Duration
+}
+
+// result is the outcome of a single run of a job.
+type result struct {
+	end    time.Time
+	failed bool
+}
+
+// alertState is the state of an AlertPolicy for a single job.
+type alertState struct {
+	consecutive int
+	results     []result // Runs within the policy's window, oldest first
+	failing     bool
+	sent        time.Time
+	suppressed  int
+}
+
+// alerter applies an AlertPolicy to the runs of jobs and sends the resulting
+// notifications.
+type alerter struct {
+	policy    AlertPolicy
+	notifiers []Notifier
+
+	mu     sync.Mutex
+	states map[*Job]*alertState
+}
+
+// reason returns a description of the condition met by the job, or an
+// empty string if none are.
+func (a *alerter) reason(state *alertState) string {
+	p := a.policy
+	if p.ConsecutiveFailures > 0 && state.consecutive >= p.ConsecutiveFailures {
+		return fmt.Sprintf("%d consecutive failures", state.consecutive)
+	}
+	if len(state.results) == 0 || len(state.results) < p.MinRuns {
+		return ""
+	}
+	var failures int
+	for _, r := range state.results {
+		if r.failed {
+			failures += 1
+		}
+	}
+	rate := float64(failures) / float64(len(state.results))
+	if rate <= p.FailureRate {
+		return ""
+	}
+	return fmt.Sprintf(
+		"%d of %d runs failed in the last %s",
+		failures, len(state.results), describeDuration(p.Window),
+	)
+}
+
+// update applies the status of a run to the job's state, and returns the
+// notification to send, if any.
+func (a *alerter) update(j *Job, s Status) (Notification, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	state, ok := a.states[j]
+	if !ok {
+		state = &alertState{}
+		a.states[j] = state
+	}
+
+	failed := s.Error != nil
+	if failed {
+		state.consecutive += 1
+	} else {
+		state.consecutive = 0
+	}
+
+	// Record the run and drop those outside the window
+	if a.policy.FailureRate > 0 && a.policy.Window > 0 {
+		state.results = append(state.results, result{s.End, failed})
+		start := s.End.Add(-a.policy.Window)
+		for !state.results[0].end.After(start) {
+			state.results = state.results[1:]
+		}
+	}
+
+	n := Notification{Job: j.Name, Status: s, Time: s.End, Suppressed: state.suppressed}
+	if n.Job == "" {
+		n.Job = fmt.Sprintf("job %d", j.ID())
+	}
+	if n.Reason = a.reason(state); n.Reason != "" {
+		if state.failing && (a.policy.Repeat <= 0 || s.End.Sub(state.sent) < a.policy.Repeat) {
+			state.suppressed += 1
+			return n, false
+		}
+		n.Kind = NotifyFailing
+	} else if state.failing && !failed {
+		n.Kind = NotifyRecovered
+	} else {
+		return n, false
+	}
+	state.failing = n.Kind == NotifyFailing
+	state.sent = s.End
+	state.suppressed = 0
+	return n, true
+}
+
+// afterRun sends the notification caused by the run, if any. Errors from the
+// Notifiers are logged by the job's scheduler.
+func (a *alerter) afterRun(j *Job, s Status) {
+	if s.Skipped {
+		return
+	}
+	n, ok := a.update(j, s)
+	if !ok {
+		return
+	}
+	for _, notifier := range a.notifiers {
+		if err := notifier.Notify(n); err != nil {
+			now := time.Now()
+			j.scheduler.logger.Log(Status{
+				Error: fmt.Errorf("schedule: notification failed: %s", err),
+				Start: now,
+				End:   now,
+			})
+		}
+	}
+}
+
+// forget removes the state of a job that has stopped.
+func (a *alerter) forget(j *Job) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.states, j)
+}
+
+// Hooks returns hooks that apply the policy to every job they are added to,
+// and send the resulting notifications to the given Notifiers. The state of
+// each job is kept separately, so they may be added to a Scheduler.
+func (p AlertPolicy) Hooks(notifiers ...Notifier) Hooks {
+	a := &alerter{
+		policy:    p,
+		notifiers: notifiers,
+		states:    make(map[*Job]*alertState),
+	}
+	return Hooks{AfterRun: a.afterRun, OnQuit: a.forget}
+}
+
+// AlertOn applies the policy to the job and sends the resulting
+// notifications to the given Notifiers.
+func AlertOn(p AlertPolicy, notifiers ...Notifier) Option {
+	return func(j *Job) {
+		j.hooks = append(j.hooks, p.Hooks(notifiers...))
+	}
+}
@@ -0,0 +1,110 @@
+package schedule
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// runAt creates the status of a run that ended at the given minute.
+func runAt(minute int, err error) Status {
+	end := time.Date(2014, time.Month(2), 14, 12, minute, 0, 0, time.UTC)
+	return Status{Start: end.Add(-time.Second), End: end, Error: err}
+}
+
+// expectKinds checks the kinds of the notifications.
+func expectKinds(t *testing.T, notifications []Notification, kinds ...NotificationKind) {
+	if len(notifications) != len(kinds) {
+		t.Fatalf("Unexpected number of notifications: %d != %d", len(notifications), len(kinds))
+	}
+	for i, k := range kinds {
+		if notifications[i].Kind != k {
+			t.Errorf("Unexpected kind of notification %d: %s != %s", i, notifications[i].Kind, k)
+		}
+	}
+}
+
+func TestAlertPolicy_ConsecutiveFailures(t *testing.T) {
+	failed := errors.New("failed")
+	memory := &MemoryNotifier{}
+	hooks := AlertPolicy{ConsecutiveFailures: 2, Repeat: 10 * time.Minute}.Hooks(memory)
+	j := &Job{Name: "backup", scheduler: New()}
+
+	for i, err := range []error{failed, nil, failed, failed, failed} {
+		hooks.AfterRun(j, runAt(i, err))
+	}
+	expectKinds(t, memory.Notifications(), NotifyFailing)
+	expectString(t, memory.Notifications()[0].String(), "backup is failing: 2 consecutive failures (last error: failed)")
+
+	// Repeats are suppressed until the interval has passed
+	hooks.AfterRun(j, runAt(13, failed))
+	hooks.AfterRun(j, runAt(14, nil))
+	hooks.AfterRun(j, runAt(15, nil))
+	notifications := memory.Notifications()
+	expectKinds(t, notifications, NotifyFailing, NotifyFailing, NotifyRecovered)
+	expectInt(t, notifications[1].Suppressed, 1)
+	expectString(t, notifications[2].String(), "backup recovered")
+
+	// Skipped runs are ignored
+	hooks.AfterRun(j, Status{Skipped: true, Error: failed})
+	expectInt(t, len(memory.Notifications()), 3)
+}
+
+func TestAlertPolicy_FailureRate(t *testing.T) {
+	failed := errors.New("failed")
+	memory := &MemoryNotifier{}
+	policy := AlertPolicy{FailureRate: 0.5, Window: 10 * time.Minute, MinRuns: 3}
+	hooks := policy.Hooks(memory)
+	j := &Job{Name: "sync", scheduler: New()}
+
+	// Two runs are too few, and one of three is not over the rate
+	hooks.AfterRun(j, runAt(0, failed))
+	hooks.AfterRun(j, runAt(1, failed))
+	expectInt(t, len(memory.Notifications()), 0)
+	hooks.AfterRun(j, runAt(2, nil))
+	expectKinds(t, memory.Notifications(), NotifyFailing)
+	expectString(t, memory.Notifications()[0].Reason, "2 of 3 runs failed in the last 10 minutes")
+
+	// The job recovers once the rate is no longer exceeded
+	hooks.AfterRun(j, runAt(5, failed))
+	expectInt(t, len(memory.Notifications()), 1)
+	hooks.AfterRun(j, runAt(6, nil))
+	expectInt(t, len(memory.Notifications()), 1)
+	hooks.AfterRun(j, runAt(7, nil))
+	expectKinds(t, memory.Notifications(), NotifyFailing, NotifyRecovered)
+
+	// Failures leave the window
+	hooks.AfterRun(j, runAt(20, failed))
+	hooks.AfterRun(j, runAt(21, nil))
+	hooks.AfterRun(j, runAt(22, nil))
+	expectInt(t, len(memory.Notifications()), 2)
+}
+
+func TestAlertOn(t *testing.T) {
+	memory := &MemoryNotifier{}
+	broken := notifierFunc(func(Notification) error { return errors.New("unreachable") })
+	var logged []Status
+	s := New()
+	s.SetLogger(loggerFunc(func(status Status) { logged = append(logged, status) }))
+
+	j := s.Whenever(func() error { return errors.New("failed") }, make(chan time.Time),
+		Named("report"),
+		AlertOn(AlertPolicy{ConsecutiveFailures: 1}, broken, memory),
+	)
+	j.Trigger()
+	j.Trigger()
+	j.Quit()
+
+	expectKinds(t, memory.Notifications(), NotifyFailing)
+
+	// Errors from notifiers are logged after the run
+	expectInt(t, len(logged), 3)
+	expectString(t, logged[1].Error.Error(), "schedule: notification failed: unreachable")
+}
+
+// notifierFunc adapts a function to the Notifier interface.
+type notifierFunc func(Notification) error
+
+func (f notifierFunc) Notify(n Notification) error {
+	return f(n)
+}
@@ -0,0 +1,73 @@
+package schedule
+
+import (
+	"time"
+)
+
+// BlackoutPolicy determines what happens to the runs of jobs that are due
+// during one of their scheduler's blackout windows.
+type BlackoutPolicy int
+
+const (
+	// BlackoutSkip logs and records a skipped Status for every run that is
+	// due during the blackout.
+	BlackoutSkip BlackoutPolicy = iota
+
+	// BlackoutDefer delays runs until the end of the blackout. Occurrences
+	// during the blackout are merged into a single run. Jobs ticked by an
+	// external time channel cannot be deferred and are skipped instead.
+	BlackoutDefer
+)
+
+// blackout is a window during which the jobs of a scheduler do not run.
+type blackout struct {
+	window Window
+	policy BlackoutPolicy
+}
+
+// AddBlackout suppresses every job on the Scheduler during the given window,
+// such as a nightly backup. Triggered runs are not suppressed.
+func (s *Scheduler) AddBlackout(w Window, policy BlackoutPolicy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blackouts = append(s.blackouts, blackout{w, policy})
+}
+
+// blackedOut returns true if the given time is within any of the Scheduler's
+// blackouts. If every containing blackout defers runs, the latest of their
+// ends is also returned.
+func (s *Scheduler) blackedOut(t time.Time) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var end time.Time
+	var contained bool
+	for _, b := range s.blackouts {
+		if !b.window.Contains(t) {
+			continue
+		}
+		if b.policy == BlackoutSkip {
+			return time.Time{}, true
+		}
+		contained = true
+		if e := b.window.EndOf(t); e.After(end) {
+			end = e
+		}
+	}
+	return end, contained
+}
+
+// holdTick handles a tick during a blackout of the job's scheduler. It
+// returns whether the run was skipped, or deferred to the end of the
+// blackout. A deferred job's tick channel is reset to the end.
+func (j *Job) holdTick() (skipped, deferred bool) {
+	end, ok := j.scheduler.blackedOut(time.Now())
+	if !ok {
+		return false, false
+	}
+	if end.IsZero() || j.schedule == nil {
+		j.skip()
+		return true, false
+	}
+	j.reset(end)
+	return false, true
+}
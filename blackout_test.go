@@ -0,0 +1,52 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+// windowAround creates a window that contains the current time and ends after
+// the given duration.
+func windowAround(d time.Duration) Window {
+	now := time.Now().UTC()
+	return NewWindow(ClockFromTime(now.Add(-time.Minute)), ClockFromTime(now.Add(d)))
+}
+
+func TestScheduler_BlackoutSkip(t *testing.T) {
+	j, tick, runs := newPauseJob()
+	j.scheduler.AddBlackout(windowAround(time.Hour), BlackoutSkip)
+	j.Run()
+
+	tick <- time.Now()
+
+	// Triggered runs are not suppressed, and guarantee the tick was handled
+	if _, err := j.Trigger(); err != nil {
+		t.Fatal(err)
+	}
+	j.Quit()
+	expectInt(t, len(runs), 1)
+
+	history := j.History()
+	expectInt(t, len(history), 2)
+	if !history[0].Skipped || history[1].Skipped {
+		t.Error("The tick during the blackout should be skipped")
+	}
+}
+
+func TestScheduler_BlackoutDefer(t *testing.T) {
+	s := New()
+	w := windowAround(2 * time.Second)
+	s.AddBlackout(w, BlackoutDefer)
+	end := w.EndOf(time.Now())
+
+	ran := make(chan time.Time, 1)
+	s.Schedule(func() error {
+		ran <- time.Now()
+		return nil
+	}, Interval(10*time.Millisecond), Times(1))
+	s.WaitForJobsToFinish()
+
+	if at := <-ran; at.Before(end) {
+		t.Errorf("The run should be deferred until %s, ran at %s", end, at)
+	}
+}
@@ -95,6 +95,36 @@ func (c Clock) next(now func() time.Time) time.Time {
 	return nxt
 }
 
+// nextAfter returns the first occurrence of the clock strictly after the given
+// time. Dates are advanced by the calendar, so days that are not 24 hours
+// long because of daylight saving time are handled.
+func (c Clock) nextAfter(after time.Time) time.Time {
+	year, month, day := after.In(c.loc).Date()
+	nxt := c.ToTime(year, month, day)
+	if !nxt.After(after) {
+		// It is safe to add more days than there are in a month
+		nxt = c.ToTime(year, month, day+1)
+	}
+	return nxt
+}
+
+// Prev returns the last occurrence of the clock strictly before the given
+// time. Like nextAfter, dates are moved by the calendar, so an occurrence on
+// a day when daylight saving time changes is the same one Next would return.
+func (c Clock) Prev(before time.Time) time.Time {
+	year, month, day := before.In(c.loc).Date()
+	prev := c.ToTime(year, month, day)
+	if !prev.Before(before) {
+		prev = c.ToTime(year, month, day-1)
+	}
+	return prev
+}
+
+// Schedule returns a Schedule that occurs daily at the clock.
+func (c Clock) Schedule() Schedule {
+	return Clocks{c}
+}
+
 // ToTime converts the given clock to a `time.Time` using the given
 // year, month, and date.
 func (c Clock) ToTime(y int, m time.Month, d int) time.Time {
@@ -209,3 +239,41 @@ func (c Clocks) Less(i, j int) bool {
 func SortClocks(clocks []Clock) {
 	sort.Sort(Clocks(clocks))
 }
+
+// Next returns the first occurrence of any of the clocks strictly after the
+// given time. Clocks implement the Schedule interface, occurring every day at
+// each clock. No clocks never occur.
+func (c Clocks) Next(after time.Time) time.Time {
+	var next time.Time
+	for _, clock := range c {
+		if t := clock.nextAfter(after); next.IsZero() || t.Before(next) {
+			next = t
+		}
+	}
+	return next
+}
+
+// Prev returns the last occurrence of any of the clocks strictly before the
+// given time. No clocks never occur.
+func (c Clocks) Prev(before time.Time) time.Time {
+	var prev time.Time
+	for _, clock := range c {
+		if t := clock.Prev(before); prev.IsZero() || t.After(prev) {
+			prev = t
+		}
+	}
+	return prev
+}
+
+// Describe returns a description such as "every day at 3:00 AM UTC".
+func (c Clocks) Describe(f HourFormat) string {
+	if len(c) == 0 {
+		return "never"
+	}
+	return fmt.Sprintf("every day at %s", describeClocks(c, f))
+}
+
+// String returns a description on a 24-hour clock.
+func (c Clocks) String() string {
+	return c.Describe(Hour24)
+}
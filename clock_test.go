@@ -105,3 +105,31 @@ func TestClock_Before(t *testing.T) {
 		t.Error("Two AM should be before six PM")
 	}
 }
+
+func TestClocks_Next(t *testing.T) {
+	clocks := Clocks{MustParseClockUTC("9:00:00"), MustParseClockUTC("17:00:00")}
+	morning := time.Date(2014, time.Month(2), 14, 8, 0, 0, 0, time.UTC)
+	expectTime(t, clocks.Next(morning), morning.Add(time.Hour))
+
+	// Occurrences are strictly after the given time
+	nine := morning.Add(time.Hour)
+	expectTime(t, clocks.Next(nine), nine.Add(8*time.Hour))
+
+	night := time.Date(2014, time.Month(2), 28, 18, 0, 0, 0, time.UTC)
+	expectTime(t, clocks.Next(night), time.Date(2014, time.Month(3), 1, 9, 0, 0, 0, time.UTC))
+
+	if !(Clocks{}).Next(night).IsZero() {
+		t.Error("No clocks should never occur")
+	}
+}
+
+func TestClocks_NextDST(t *testing.T) {
+	denver, err := time.LoadLocation("America/Denver")
+	if err != nil {
+		t.Skip(err)
+	}
+	// Clocks spring forward on 2014-03-09, a day of only 23 hours
+	clocks := Clocks{MustParseClockIn("3:00:00", denver)}
+	before := time.Date(2014, time.Month(3), 8, 3, 0, 0, 0, denver)
+	expectTime(t, clocks.Next(before), time.Date(2014, time.Month(3), 9, 3, 0, 0, 0, denver))
+}
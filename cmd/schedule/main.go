@@ -0,0 +1,219 @@
+// Command schedule previews and checks schedules.
+//
+// Usage:
+//
+//	schedule next [flags]             print the next occurrences of a schedule
+//	schedule explain [flags]          describe a schedule in words
+//	schedule validate <config file>   check a configuration file
+//
+// Schedules are given with the same fields as a configuration file:
+//
+//	schedule next -cron "30 1 * * mon-fri" -tz America/Denver -n 5
+//	schedule next -clock 9:00:00,17:00:00 -weekdays mon,fri
+//	schedule explain -every 15m
+//	schedule explain -config jobs.conf
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aodin/schedule"
+)
+
+// timeFormat is the format of printed occurrences.
+const timeFormat = "Mon 2006-01-02 15:04:05 MST"
+
+const usage = `usage: schedule <command> [flags]
+
+commands:
+  next      print the next occurrences of a schedule
+  explain   describe a schedule in words
+  validate  check a configuration file
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command with the given arguments and returns its exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+	switch args[0] {
+	case "next":
+		return next(args[1:], stdout, stderr)
+	case "explain":
+		return explain(args[1:], stdout, stderr)
+	case "validate":
+		return validate(args[1:], stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return 0
+	}
+	fmt.Fprintf(stderr, "schedule: unknown command %q\n\n%s", args[0], usage)
+	return 2
+}
+
+// scheduleFlags are the flags that build a schedule.
+type scheduleFlags struct {
+	cron     string
+	clock    string
+	weekdays string
+	every    string
+	timezone string
+}
+
+func (sf *scheduleFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&sf.cron, "cron", "", "a five field cron `expression`")
+	fs.StringVar(&sf.clock, "clock", "", "a comma separated list of daily `clocks`, such as 9:00:00")
+	fs.StringVar(&sf.weekdays, "weekdays", "", "a comma separated list of `weekdays` for the clocks")
+	fs.StringVar(&sf.every, "every", "", "an `interval`, such as 15m")
+	fs.StringVar(&sf.timezone, "tz", "", "the `timezone` of the schedule, such as America/Denver (default local)")
+}
+
+func split(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// schedule builds the schedule with the same rules as a configuration file.
+func (sf *scheduleFlags) schedule() (schedule.Schedule, error) {
+	jc := schedule.JobConfig{
+		Cron:     sf.cron,
+		Clock:    split(sf.clock),
+		Weekdays: split(sf.weekdays),
+		Every:    sf.every,
+		Timezone: sf.timezone,
+	}
+	return jc.Schedule()
+}
+
+// location returns the timezone of the schedule.
+func (sf *scheduleFlags) location() (*time.Location, error) {
+	if sf.timezone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(sf.timezone)
+}
+
+// parseFrom parses the start time in the given location. An empty value is
+// the current time.
+func parseFrom(value string, loc *time.Location) (time.Time, error) {
+	if value == "" {
+		return time.Now().In(loc), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", value)
+}
+
+func next(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("next", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var sf scheduleFlags
+	sf.register(fs)
+	n := fs.Int("n", 10, "the `number` of occurrences to print")
+	from := fs.String("from", "", "print occurrences after this `time`, such as 2014-02-14 09:00 (default now)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	s, err := sf.schedule()
+	if err != nil {
+		fmt.Fprintf(stderr, "schedule: %s\n", err)
+		return 1
+	}
+	loc, err := sf.location()
+	if err != nil {
+		fmt.Fprintf(stderr, "schedule: %s\n", err)
+		return 1
+	}
+	t, err := parseFrom(*from, loc)
+	if err != nil {
+		fmt.Fprintf(stderr, "schedule: %s\n", err)
+		return 1
+	}
+
+	for _, next := range schedule.NextN(s, t, *n) {
+		fmt.Fprintln(stdout, next.In(loc).Format(timeFormat))
+	}
+	return 0
+}
+
+func explain(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("explain", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var sf scheduleFlags
+	sf.register(fs)
+	path := fs.String("config", "", "describe every job of the configuration `file` instead")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if *path == "" {
+		s, err := sf.schedule()
+		if err != nil {
+			fmt.Fprintf(stderr, "schedule: %s\n", err)
+			return 1
+		}
+		fmt.Fprintln(stdout, schedule.Describe(s))
+		return 0
+	}
+
+	c, err := schedule.LoadConfig(*path)
+	if err != nil {
+		printError(stderr, *path, err)
+		return 1
+	}
+	for _, jc := range c.Jobs {
+		s, _ := jc.Schedule()
+		desc := schedule.Describe(s)
+		if !jc.IsEnabled() {
+			desc += " (disabled)"
+		}
+		fmt.Fprintf(stdout, "%s: %s\n", jc.Name, desc)
+	}
+	return 0
+}
+
+func validate(args []string, stdout, stderr io.Writer) int {
+	if len(args) != 1 {
+		fmt.Fprintln(stderr, "usage: schedule validate <config file>")
+		return 2
+	}
+	path := args[0]
+	c, err := schedule.LoadConfig(path)
+	if err != nil {
+		printError(stderr, path, err)
+		return 1
+	}
+	fmt.Fprintf(stdout, "%s: %d jobs OK\n", path, len(c.Jobs))
+	return 0
+}
+
+// printError prints every error of a configuration prefixed by its path.
+func printError(w io.Writer, path string, err error) {
+	if errs, ok := err.(schedule.ConfigErrors); ok {
+		for _, e := range errs {
+			fmt.Fprintf(w, "%s:%d: %s: %s\n", path, e.Line, e.Job, e.Err)
+		}
+		return
+	}
+	if e, ok := err.(*schedule.ConfigError); ok {
+		fmt.Fprintf(w, "%s:%d: %s\n", path, e.Line, e.Err)
+		return
+	}
+	fmt.Fprintf(w, "schedule: %s\n", err)
+}
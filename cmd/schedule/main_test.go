@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runCommand(t *testing.T, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func expectOutput(t *testing.T, output, expected string) {
+	if output != expected {
+		t.Errorf("Unexpected output:\n%s\nexpected:\n%s", output, expected)
+	}
+}
+
+func TestNext(t *testing.T) {
+	code, out, stderr := runCommand(t, "next",
+		"-cron", "30 1 * * mon-fri", "-tz", "UTC", "-n", "3", "-from", "2014-02-14 12:00",
+	)
+	if code != 0 {
+		t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+	}
+	expectOutput(t, out, `Mon 2014-02-17 01:30:00 UTC
+Tue 2014-02-18 01:30:00 UTC
+Wed 2014-02-19 01:30:00 UTC
+`)
+
+	code, out, _ = runCommand(t, "next",
+		"-clock", "17:00:00,9:00:00", "-weekdays", "fri,mon", "-tz", "UTC", "-n", "3", "-from", "2014-02-14 12:00",
+	)
+	if code != 0 {
+		t.Fatalf("Unexpected exit code %d", code)
+	}
+	expectOutput(t, out, `Fri 2014-02-14 17:00:00 UTC
+Mon 2014-02-17 09:00:00 UTC
+Mon 2014-02-17 17:00:00 UTC
+`)
+
+	if code, _, _ := runCommand(t, "next", "-cron", "61 * * * *"); code != 1 {
+		t.Errorf("An invalid schedule should exit with 1, got %d", code)
+	}
+	if code, _, _ := runCommand(t, "next", "-every", "1h", "-from", "tomorrow"); code != 1 {
+		t.Errorf("An invalid start time should exit with 1, got %d", code)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	_, out, _ := runCommand(t, "explain", "-every", "15m")
+	expectOutput(t, out, "every 15 minutes\n")
+
+	path := filepath.Join(t.TempDir(), "jobs.conf")
+	config := "backup cron 30 1 * * * timezone=UTC\nreport clock 9:00:00 weekdays=mon enabled=false\n"
+	if err := os.WriteFile(path, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, out, _ = runCommand(t, "explain", "-config", path)
+	expectOutput(t, out, "backup: every day at 1:30 UTC\nreport: Mon at 9:00 (disabled)\n")
+}
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.conf")
+	invalid := filepath.Join(dir, "invalid.conf")
+	os.WriteFile(valid, []byte("a every 5m\n"), 0644)
+	os.WriteFile(invalid, []byte("a every 5m\nb every soon\n\nc cron * * *\n"), 0644)
+
+	code, out, _ := runCommand(t, "validate", valid)
+	if code != 0 {
+		t.Errorf("A valid configuration should exit with 0, got %d", code)
+	}
+	expectOutput(t, out, valid+": 1 jobs OK\n")
+
+	code, _, stderr := runCommand(t, "validate", invalid)
+	if code != 1 {
+		t.Errorf("An invalid configuration should exit with 1, got %d", code)
+	}
+	lines := strings.Split(strings.TrimSpace(stderr), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], invalid+":2: b: ") || !strings.HasPrefix(lines[1], invalid+":4: c: ") {
+		t.Errorf("Unexpected errors:\n%s", stderr)
+	}
+}
+
+func TestRun(t *testing.T) {
+	if code, _, _ := runCommand(t); code != 2 {
+		t.Errorf("No command should exit with 2, got %d", code)
+	}
+	if code, _, _ := runCommand(t, "later"); code != 2 {
+		t.Errorf("An unknown command should exit with 2, got %d", code)
+	}
+}
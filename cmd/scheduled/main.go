@@ -0,0 +1,194 @@
+// Command scheduled is a daemon that runs shell commands on schedules, as a
+// replacement for crontab.
+//
+// Usage:
+//
+//	scheduled -config jobs.conf [-logs dir] [-poll 10s] [-grace 30s]
+//
+// Every job of the configuration file must have a command:
+//
+//	# name  schedule          options      command
+//	backup  cron 30 1 * * *   timeout=1h   -- /usr/local/bin/backup --full
+//	clean   every 1h          timeout=5m   -- find /tmp -mtime +7 -delete
+//
+// The configuration is reloaded when it changes or a SIGHUP is received. The
+// output and exit code of every run are written to a log file in the logs
+// directory that is rotated daily, such as scheduled_2014-02-14.log. On
+// SIGTERM or SIGINT, no new runs are started and the daemon exits once the
+// runs in progress finish, or the grace period ends.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/aodin/schedule"
+)
+
+func main() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+	os.Exit(run(os.Args[1:], os.Stderr, signals))
+}
+
+// rotatingFile is a writer to a file that is named after the current date,
+// so a new file is started every day.
+type rotatingFile struct {
+	dir  string
+	name string
+	now  func() time.Time
+
+	mu   sync.Mutex
+	path string
+	f    *os.File
+}
+
+// Write writes to the file of the current date, opening it if needed.
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	path := filepath.Join(r.dir, schedule.TimestampFilename(r.now(), r.name))
+	if path != r.path {
+		if r.f != nil {
+			r.f.Close()
+		}
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+		if err != nil {
+			r.f, r.path = nil, ""
+			return 0, err
+		}
+		r.f, r.path = f, path
+	}
+	return r.f.Write(p)
+}
+
+// Close closes the current file.
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.f == nil {
+		return nil
+	}
+	err := r.f.Close()
+	r.f, r.path = nil, ""
+	return err
+}
+
+// daemonLogger writes the summaries of reloads and any other statuses, such
+// as skipped runs and alerts, to the log. Runs are logged with the names of
+// their jobs by an AfterRun hook instead.
+type daemonLogger struct {
+	log *log.Logger
+}
+
+func (l daemonLogger) Log(s schedule.Status) {
+	if !isRun(s) {
+		l.log.Println(s)
+	}
+}
+
+// isRun returns true if the status is the result of a run. Every job of the
+// daemon runs a command, which records a non-zero exit code if it fails, so
+// other failures are events such as alerts.
+func isRun(s schedule.Status) bool {
+	if s.Skipped {
+		return false
+	}
+	return s.Error == nil || s.ExitCode != 0 || errors.Is(s.Error, exec.ErrWaitDelay)
+}
+
+func (l daemonLogger) LogReload(rs schedule.ReloadSummary) {
+	l.log.Println(rs)
+}
+
+// indent prefixes every line of the output with a tab.
+func indent(output string) string {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	return "\t" + strings.Join(lines, "\n\t")
+}
+
+// formatRun formats the status of a run of the named job.
+func formatRun(name string, s schedule.Status) string {
+	out := fmt.Sprintf("%s: %s exit=%d", name, s, s.ExitCode)
+	if s.Stdout != "" {
+		out += "\n\tstdout:\n" + indent(s.Stdout)
+	}
+	if s.Stderr != "" {
+		out += "\n\tstderr:\n" + indent(s.Stderr)
+	}
+	return out
+}
+
+// run runs the daemon until a signal is received and returns its exit code.
+func run(args []string, stderr io.Writer, signals <-chan os.Signal) int {
+	fs := flag.NewFlagSet("scheduled", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	path := fs.String("config", "", "the configuration `file` of jobs")
+	logs := fs.String("logs", ".", "the `directory` of the daily log files")
+	poll := fs.Duration("poll", 10*time.Second, "how often to check the configuration for changes")
+	grace := fs.Duration("grace", 30*time.Second, "how long to wait for runs in progress on shutdown")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *path == "" {
+		fmt.Fprintln(stderr, "scheduled: a -config file is required")
+		return 2
+	}
+
+	// Every job must run a command, since no functions are registered
+	c, err := schedule.LoadConfig(*path)
+	if err != nil {
+		fmt.Fprintf(stderr, "scheduled: %s: %s\n", *path, err)
+		return 1
+	}
+	for _, jc := range c.Jobs {
+		if jc.Command == "" {
+			fmt.Fprintf(stderr, "scheduled: %s:%d: %s: a command is required\n", *path, jc.Line, jc.Name)
+			return 1
+		}
+	}
+
+	file := &rotatingFile{dir: *logs, name: "scheduled.log", now: time.Now}
+	defer file.Close()
+	logger := log.New(file, "", log.LstdFlags)
+
+	s := schedule.New()
+	s.SetLogger(daemonLogger{logger})
+	s.AddHooks(schedule.Hooks{
+		AfterRun: func(j *schedule.Job, status schedule.Status) {
+			logger.Println(formatRun(j.Name, status))
+		},
+	})
+
+	r, err := s.Watch(*path, *poll)
+	if err != nil {
+		fmt.Fprintf(stderr, "scheduled: %s: %s\n", *path, err)
+		return 1
+	}
+	logger.Printf("started with %d jobs from %s", len(r.Jobs()), *path)
+
+	sig := <-signals
+	logger.Printf("received %s, waiting up to %s for runs in progress", sig, *grace)
+	r.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *grace)
+	defer cancel()
+	if err := s.Shutdown(ctx); err != nil {
+		logger.Printf("shutdown: %s", err)
+		return 1
+	}
+	logger.Println("stopped")
+	return 0
+}
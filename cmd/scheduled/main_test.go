@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/aodin/schedule"
+)
+
+func TestRotatingFile(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2014, time.Month(2), 14, 23, 59, 0, 0, time.UTC)
+	file := &rotatingFile{dir: dir, name: "scheduled.log", now: func() time.Time { return now }}
+	defer file.Close()
+
+	file.Write([]byte("first\n"))
+	now = now.Add(time.Minute)
+	file.Write([]byte("second\n"))
+
+	for name, expected := range map[string]string{
+		"scheduled_2014-02-14.log": "first\n",
+		"scheduled_2014-02-15.log": "second\n",
+	} {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(content) != expected {
+			t.Errorf("Unexpected content of %s: %q", name, content)
+		}
+	}
+}
+
+func TestFormatRun(t *testing.T) {
+	start := time.Date(2014, time.Month(2), 14, 0, 0, 0, 0, time.UTC)
+	status := schedule.Status{
+		Error:    errors.New("exit status 2"),
+		Start:    start,
+		End:      start.Add(time.Millisecond),
+		Stdout:   "one\ntwo\n",
+		ExitCode: 2,
+	}
+	expected := "backup: ERROR: exit status 2 (1.000 ms) exit=2\n\tstdout:\n\tone\n\ttwo"
+	if out := formatRun("backup", status); out != expected {
+		t.Errorf("Unexpected format:\n%s", out)
+	}
+}
+
+func TestDaemonLogger(t *testing.T) {
+	var out bytes.Buffer
+	logger := daemonLogger{log.New(&out, "", 0)}
+	now := time.Date(2014, time.Month(2), 14, 0, 0, 0, 0, time.UTC)
+
+	// Runs are logged by a hook, however long they took, but other statuses
+	// are not dropped
+	logger.Log(schedule.Status{Start: now, End: now.Add(time.Second)})
+	logger.Log(schedule.Status{Start: now, End: now})
+	logger.Log(schedule.Status{Start: now, End: now, Error: errors.New("exit status 1"), ExitCode: 1})
+	logger.Log(schedule.Status{Start: now, End: now, Error: errors.New("schedule: notification failed")})
+	logger.Log(schedule.Status{Start: now, End: now.Add(time.Second), Error: errors.New("schedule: job is late")})
+	logger.Log(schedule.Status{Start: now, End: now, Skipped: true})
+	expected := "ERROR: schedule: notification failed (0.000 ms)\n" +
+		"ERROR: schedule: job is late (1000.000 ms)\nSKIPPED\n"
+	if out.String() != expected {
+		t.Errorf("Unexpected log:\n%s", out.String())
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "jobs.conf")
+	config := "hello every 10ms timeout=1s -- echo hello; echo oops >&2\n"
+	if err := os.WriteFile(path, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	signals := make(chan os.Signal, 1)
+	result := make(chan int, 1)
+	var stderr bytes.Buffer
+	go func() {
+		result <- run([]string{"-config", path, "-logs", dir, "-grace", "5s"}, &stderr, signals)
+	}()
+
+	// Wait for a run to be logged
+	logPath := filepath.Join(dir, schedule.TimestampFilename(time.Now(), "scheduled.log"))
+	var content []byte
+	for i := 0; i < 500; i += 1 {
+		content, _ = os.ReadFile(logPath)
+		if strings.Contains(string(content), "\tstderr:\n\toops") {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !strings.Contains(string(content), "hello: OK") || !strings.Contains(string(content), "\thello") {
+		t.Errorf("The run should be logged with its output:\n%s", content)
+	}
+
+	signals <- syscall.SIGTERM
+	if code := <-result; code != 0 {
+		t.Errorf("Unexpected exit code %d: %s", code, stderr.String())
+	}
+	content, _ = os.ReadFile(logPath)
+	if !strings.HasSuffix(strings.TrimSpace(string(content)), "stopped") {
+		t.Errorf("The shutdown should be logged:\n%s", content)
+	}
+}
+
+func TestRun_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "jobs.conf")
+	os.WriteFile(path, []byte("hello every 1h\n"), 0644)
+
+	var stderr bytes.Buffer
+	if code := run([]string{"-config", path}, &stderr, nil); code != 1 {
+		t.Errorf("A job without a command should exit with 1, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "jobs.conf:1: hello: a command is required") {
+		t.Errorf("Unexpected error: %s", stderr.String())
+	}
+	if code := run(nil, &stderr, nil); code != 2 {
+		t.Errorf("A missing configuration should exit with 2, got %d", code)
+	}
+}
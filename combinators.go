@@ -0,0 +1,258 @@
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxSearch is the number of occurrences a combinator will examine while
+// searching for its next occurrence. Combinators whose occurrences are too
+// sparse, such as the intersection of two disjoint schedules, will stop
+// occurring rather than search forever.
+const maxSearch = 100000
+
+// Condition reports whether a time is included. Conditions are used to
+// filter the occurrences of a schedule.
+type Condition func(time.Time) bool
+
+// relative is implemented by schedules whose occurrences are measured from
+// the time they are given, such as an Interval, rather than fixed in time.
+type relative interface {
+	relative() bool
+}
+
+// isRelative returns true if the schedule is relative.
+func isRelative(s Schedule) bool {
+	r, ok := s.(relative)
+	return ok && r.relative()
+}
+
+// atOrAfter returns the first occurrence of the schedule at or after the
+// given time. Relative schedules first occur at the time itself.
+func atOrAfter(s Schedule, t time.Time) time.Time {
+	if isRelative(s) {
+		return t
+	}
+	return s.Next(t.Add(-time.Nanosecond))
+}
+
+func describeAll(schedules []Schedule, sep string, f HourFormat) string {
+	descs := make([]string, len(schedules))
+	for i, s := range schedules {
+		descs[i] = DescribeWith(s, f)
+	}
+	return strings.Join(descs, sep)
+}
+
+type union []Schedule
+
+// Union returns a Schedule that occurs whenever any of the given schedules
+// occur. Simultaneous occurrences are merged.
+func Union(schedules ...Schedule) Schedule {
+	return union(schedules)
+}
+
+func (u union) Next(after time.Time) time.Time {
+	var next time.Time
+	for _, s := range u {
+		if t := s.Next(after); !t.IsZero() && (next.IsZero() || t.Before(next)) {
+			next = t
+		}
+	}
+	return next
+}
+
+func (u union) Describe(f HourFormat) string {
+	return describeAll(u, " or ", f)
+}
+
+func (u union) String() string {
+	return u.Describe(Hour24)
+}
+
+type intersection []Schedule
+
+// Intersect returns a Schedule that occurs only when all of the given
+// schedules occur at the same instant. No schedules never occur.
+func Intersect(schedules ...Schedule) Schedule {
+	return intersection(schedules)
+}
+
+func (in intersection) Next(after time.Time) time.Time {
+	if len(in) == 0 {
+		return time.Time{}
+	}
+	t := in[0].Next(after)
+	for i := 0; i < maxSearch && !t.IsZero(); i += 1 {
+		// Find the latest of the first occurrences at or after t
+		latest := t
+		for _, s := range in {
+			next := atOrAfter(s, t)
+			if next.IsZero() {
+				return time.Time{}
+			}
+			if next.After(latest) {
+				latest = next
+			}
+		}
+		if latest.Equal(t) {
+			return t
+		}
+		t = latest
+	}
+	return time.Time{}
+}
+
+func (in intersection) Describe(f HourFormat) string {
+	return describeAll(in, " and ", f)
+}
+
+func (in intersection) String() string {
+	return in.Describe(Hour24)
+}
+
+type filter struct {
+	schedule Schedule
+	include  Condition
+	reason   string   // Why occurrences are filtered
+	excluded Schedule // Set by Except
+}
+
+// Filter returns a Schedule that only includes the occurrences of the given
+// schedule for which the condition is true, such as "every 15 minutes but
+// only during business hours".
+func Filter(s Schedule, include Condition) Schedule {
+	return filter{s, include, "when included", nil}
+}
+
+// Reject returns a Schedule that excludes the occurrences of the given
+// schedule for which the condition is true, such as "daily at 03:00 except
+// on the 1st".
+func Reject(s Schedule, exclude Condition) Schedule {
+	include := func(t time.Time) bool { return !exclude(t) }
+	return filter{s, include, "unless excluded", nil}
+}
+
+// Except returns a Schedule that includes the occurrences of the given
+// schedule that are not also occurrences of the excluded schedule. Relative
+// schedules, such as an Interval, have no fixed occurrences to exclude.
+func Except(s, excluded Schedule) Schedule {
+	include := func(t time.Time) bool {
+		return isRelative(excluded) || !atOrAfter(excluded, t).Equal(t)
+	}
+	return filter{s, include, "", excluded}
+}
+
+func (f filter) Next(after time.Time) time.Time {
+	t := f.schedule.Next(after)
+	for i := 0; i < maxSearch && !t.IsZero(); i += 1 {
+		if f.include(t) {
+			return t
+		}
+		t = f.schedule.Next(t)
+	}
+	return time.Time{}
+}
+
+func (f filter) Describe(hf HourFormat) string {
+	if f.excluded != nil {
+		return fmt.Sprintf("%s, except %s", DescribeWith(f.schedule, hf), DescribeWith(f.excluded, hf))
+	}
+	return fmt.Sprintf("%s, %s", DescribeWith(f.schedule, hf), f.reason)
+}
+
+func (f filter) String() string {
+	return f.Describe(Hour24)
+}
+
+type limit struct {
+	schedule Schedule
+	start    time.Time
+	n        int
+}
+
+// Limit returns a Schedule of only the first n occurrences of the given
+// schedule at or after the start time. Since schedules are computed from
+// the time alone, the occurrences are counted from the start time rather
+// than from when a job starts.
+func Limit(s Schedule, start time.Time, n int) Schedule {
+	return limit{s, start, n}
+}
+
+func (l limit) Next(after time.Time) time.Time {
+	t := atOrAfter(l.schedule, l.start)
+	for i := 0; i < l.n && !t.IsZero(); i += 1 {
+		if t.After(after) {
+			return t
+		}
+		t = l.schedule.Next(t)
+	}
+	return time.Time{}
+}
+
+func (l limit) Describe(f HourFormat) string {
+	return fmt.Sprintf("the first %d occurrences of %s", l.n, DescribeWith(l.schedule, f))
+}
+
+func (l limit) String() string {
+	return l.Describe(Hour24)
+}
+
+type startAt struct {
+	schedule Schedule
+	start    time.Time
+}
+
+// StartAt returns a Schedule of the occurrences of the given schedule at or
+// after the start time.
+func StartAt(s Schedule, start time.Time) Schedule {
+	return startAt{s, start}
+}
+
+func (s startAt) Next(after time.Time) time.Time {
+	if after.Before(s.start) {
+		return atOrAfter(s.schedule, s.start)
+	}
+	return s.schedule.Next(after)
+}
+
+func (s startAt) Describe(f HourFormat) string {
+	return fmt.Sprintf("%s, starting %s", DescribeWith(s.schedule, f), s.start.Format("2006-01-02 15:04:05 MST"))
+}
+
+func (s startAt) String() string {
+	return s.Describe(Hour24)
+}
+
+type endAt struct {
+	schedule Schedule
+	end      time.Time
+}
+
+// EndAt returns a Schedule of the occurrences of the given schedule at or
+// before the end time.
+func EndAt(s Schedule, end time.Time) Schedule {
+	return endAt{s, end}
+}
+
+func (e endAt) Next(after time.Time) time.Time {
+	if t := e.schedule.Next(after); !t.After(e.end) {
+		return t
+	}
+	return time.Time{}
+}
+
+func (e endAt) Describe(f HourFormat) string {
+	return fmt.Sprintf("%s, until %s", DescribeWith(e.schedule, f), e.end.Format("2006-01-02 15:04:05 MST"))
+}
+
+func (e endAt) String() string {
+	return e.Describe(Hour24)
+}
+
+// Between returns a Schedule of the occurrences of the given schedule from
+// the start time until the end time, inclusive.
+func Between(s Schedule, start, end time.Time) Schedule {
+	return EndAt(StartAt(s, start), end)
+}
@@ -0,0 +1,176 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+// expectOccurrences checks the successive occurrences of a schedule after
+// the given time.
+func expectOccurrences(t *testing.T, s Schedule, after time.Time, expected ...time.Time) {
+	for i, e := range expected {
+		next := s.Next(after)
+		if !next.Equal(e) {
+			t.Fatalf("Unexpected occurrence %d: %s != %s", i, next, e)
+		}
+		after = next
+	}
+}
+
+func loadDenver(t *testing.T) *time.Location {
+	denver, err := time.LoadLocation("America/Denver")
+	if err != nil {
+		t.Skip(err)
+	}
+	return denver
+}
+
+func TestUnion(t *testing.T) {
+	denver := loadDenver(t)
+	u := Union(
+		Clocks{MustParseClockIn("1:30:00", denver)},
+		Clocks{MustParseClockIn("3:00:00", denver)},
+		Never,
+	)
+
+	// Clocks fall back at 2:00 on 2014-11-02, so 1:30 occurs twice but
+	// only the first is an occurrence
+	expectOccurrences(t, u,
+		time.Date(2014, time.Month(11), 1, 12, 0, 0, 0, denver),
+		time.Date(2014, time.Month(11), 2, 1, 30, 0, 0, denver),
+		time.Date(2014, time.Month(11), 2, 3, 0, 0, 0, denver),
+		time.Date(2014, time.Month(11), 3, 1, 30, 0, 0, denver),
+	)
+	expectString(t, Describe(u), "every day at 1:30 America/Denver or every day at 3:00 America/Denver or never")
+
+	if !Union().Next(time.Now()).IsZero() {
+		t.Error("An empty union should never occur")
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	// Weekdays at 9:00 intersected with daily at 9:00 and 17:00
+	in := Intersect(
+		DayClocks{Workweek, []Clock{MustParseClockUTC("9:00:00")}},
+		Clocks{MustParseClockUTC("9:00:00"), MustParseClockUTC("17:00:00")},
+	)
+	friday := time.Date(2014, time.Month(2), 14, 12, 0, 0, 0, time.UTC)
+	expectOccurrences(t, in, friday,
+		time.Date(2014, time.Month(2), 17, 9, 0, 0, 0, time.UTC),
+		time.Date(2014, time.Month(2), 18, 9, 0, 0, 0, time.UTC),
+	)
+
+	// Disjoint schedules never occur
+	disjoint := Intersect(Clocks{MustParseClockUTC("9:00:00")}, Clocks{MustParseClockUTC("10:00:00")})
+	if !disjoint.Next(friday).IsZero() {
+		t.Error("Disjoint schedules should never occur")
+	}
+	if !Intersect().Next(friday).IsZero() {
+		t.Error("An empty intersection should never occur")
+	}
+}
+
+func TestFilter(t *testing.T) {
+	denver := loadDenver(t)
+	businessHours := func(t time.Time) bool {
+		hour := t.In(denver).Hour()
+		return hour >= 9 && hour < 17
+	}
+	f := Filter(Interval(15*time.Minute), businessHours)
+
+	// Clocks spring forward at 2:00 on 2014-03-09, but the first
+	// occurrence in business hours is still at 9:05 local time
+	expectOccurrences(t, f,
+		time.Date(2014, time.Month(3), 8, 16, 50, 0, 0, denver),
+		time.Date(2014, time.Month(3), 9, 9, 5, 0, 0, denver),
+		time.Date(2014, time.Month(3), 9, 9, 20, 0, 0, denver),
+	)
+
+	// A condition that is never true never occurs
+	none := Filter(Interval(time.Hour), func(time.Time) bool { return false })
+	if !none.Next(time.Now()).IsZero() {
+		t.Error("A filter that excludes everything should never occur")
+	}
+}
+
+func TestReject(t *testing.T) {
+	denver := loadDenver(t)
+	first := func(t time.Time) bool { return t.Day() == 1 }
+	r := Reject(Clocks{MustParseClockIn("3:00:00", denver)}, first)
+
+	// Skip the 1st, and then cross the fall back on the 2nd
+	expectOccurrences(t, r,
+		time.Date(2014, time.Month(10), 30, 4, 0, 0, 0, denver),
+		time.Date(2014, time.Month(10), 31, 3, 0, 0, 0, denver),
+		time.Date(2014, time.Month(11), 2, 3, 0, 0, 0, denver),
+		time.Date(2014, time.Month(11), 3, 3, 0, 0, 0, denver),
+	)
+}
+
+func TestExcept(t *testing.T) {
+	daily := Clocks{MustParseClockUTC("3:00:00")}
+	holiday := time.Date(2014, time.Month(12), 25, 3, 0, 0, 0, time.UTC)
+	e := Except(daily, At(holiday))
+	expectOccurrences(t, e,
+		time.Date(2014, time.Month(12), 24, 0, 0, 0, 0, time.UTC),
+		time.Date(2014, time.Month(12), 24, 3, 0, 0, 0, time.UTC),
+		time.Date(2014, time.Month(12), 26, 3, 0, 0, 0, time.UTC),
+	)
+	expectString(t, Describe(e), "every day at 3:00 UTC, except once at 2014-12-25 03:00:00 UTC")
+}
+
+func TestLimit(t *testing.T) {
+	daily := Clocks{MustParseClockUTC("3:00:00")}
+	start := time.Date(2014, time.Month(2), 14, 3, 0, 0, 0, time.UTC)
+	l := Limit(daily, start, 2)
+
+	// The start time itself is included
+	expectOccurrences(t, l, start.Add(-time.Hour), start, start.AddDate(0, 0, 1))
+	if !l.Next(start.AddDate(0, 0, 1)).IsZero() {
+		t.Error("The limit should have been reached")
+	}
+}
+
+func TestLimit_Interval(t *testing.T) {
+	// Relative schedules first occur at the start time
+	start := time.Date(2014, time.Month(1), 1, 0, 0, 0, 0, time.UTC)
+	l := Limit(Interval(time.Hour), start, 3)
+	expectOccurrences(t, l, start.Add(-time.Minute),
+		start,
+		start.Add(time.Hour),
+		start.Add(2*time.Hour),
+	)
+	if !l.Next(start.Add(2 * time.Hour)).IsZero() {
+		t.Error("The limit should have been reached")
+	}
+
+	// Nothing is excluded by a relative schedule
+	daily := Clocks{MustParseClockUTC("3:00:00")}
+	expectTime(t, Except(daily, Interval(time.Hour)).Next(start), daily.Next(start))
+}
+
+func TestBetween_Interval(t *testing.T) {
+	start := time.Date(2014, time.Month(1), 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(90 * time.Minute)
+	b := Between(Interval(time.Hour), start, end)
+	expectOccurrences(t, b, start.AddDate(0, 0, -1), start, start.Add(time.Hour))
+	if !b.Next(start.Add(time.Hour)).IsZero() {
+		t.Error("There should be no occurrences after the end")
+	}
+	expectTime(t, StartAt(Offset(Interval(time.Hour), time.Minute), start).Next(start.Add(-time.Second)), start)
+}
+
+func TestBetween(t *testing.T) {
+	daily := Clocks{MustParseClockUTC("3:00:00")}
+	start := time.Date(2014, time.Month(2), 14, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2014, time.Month(2), 15, 3, 0, 0, 0, time.UTC)
+	b := Between(daily, start, end)
+
+	expectOccurrences(t, b, start.AddDate(0, 0, -10),
+		time.Date(2014, time.Month(2), 14, 3, 0, 0, 0, time.UTC),
+		end,
+	)
+	if !b.Next(end).IsZero() {
+		t.Error("There should be no occurrences after the end")
+	}
+}
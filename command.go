@@ -0,0 +1,81 @@
+package schedule
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"time"
+)
+
+// maxOutput is the number of bytes of a command's stdout and stderr that are
+// kept in its Status. Only the end of longer output is kept.
+const maxOutput = 64 * 1024
+
+// waitDelay is how long a command's output is read after it is killed.
+// Processes it started in another process group may hold its output open.
+const waitDelay = time.Second
+
+// statusKey is the context key of the Status of the current run.
+type statusKey struct{}
+
+// tail is a writer that keeps only the last bytes written to it.
+type tail struct {
+	buf bytes.Buffer
+	max int
+}
+
+func (t *tail) Write(p []byte) (int, error) {
+	n := len(p)
+	if len(p) > t.max {
+		p = p[len(p)-t.max:]
+	}
+	if over := t.buf.Len() + len(p) - t.max; over > 0 {
+		t.buf.Next(over)
+	}
+	t.buf.Write(p)
+	return n, nil
+}
+
+func (t *tail) String() string {
+	return t.buf.String()
+}
+
+// Command returns a function that runs the named program with the given
+// arguments. The program and any processes it started are killed if the
+// run's context is cancelled, such as by the Timeout option. Its stdout,
+// stderr, and exit code are recorded in the Status of the run, and a non-zero
+// exit code is an error.
+func Command(name string, args ...string) JobFunc {
+	return func(ctx context.Context) error {
+		return runCommand(ctx, exec.CommandContext(ctx, name, args...))
+	}
+}
+
+// Shell returns a function that runs the command line with /bin/sh. See
+// Command.
+func Shell(command string) JobFunc {
+	return func(ctx context.Context) error {
+		return runCommand(ctx, exec.CommandContext(ctx, "/bin/sh", "-c", command))
+	}
+}
+
+// runCommand runs the command and records its output in the Status of the
+// run in the context.
+func runCommand(ctx context.Context, cmd *exec.Cmd) error {
+	stdout := &tail{max: maxOutput}
+	stderr := &tail{max: maxOutput}
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	cmd.WaitDelay = waitDelay
+	killGroup(cmd)
+	err := cmd.Run()
+
+	if status, ok := ctx.Value(statusKey{}).(*Status); ok {
+		status.Stdout = stdout.String()
+		status.Stderr = stderr.String()
+		status.ExitCode = -1
+		if cmd.ProcessState != nil {
+			status.ExitCode = cmd.ProcessState.ExitCode()
+		}
+	}
+	return err
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package schedule
+
+import (
+	"os/exec"
+)
+
+// killGroup does nothing on systems without process groups. Only the
+// command itself is killed when its context is cancelled.
+func killGroup(cmd *exec.Cmd) {}
@@ -0,0 +1,55 @@
+package schedule
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShell(t *testing.T) {
+	s := New()
+	j := s.ScheduleCtx(Shell("echo out; echo err >&2; exit 3"), Never, Immediately())
+	s.WaitForJobsToFinish()
+
+	status := j.History()[0]
+	expectString(t, status.Stdout, "out\n")
+	expectString(t, status.Stderr, "err\n")
+	expectInt(t, status.ExitCode, 3)
+	if status.Error == nil {
+		t.Error("A non-zero exit code should be an error")
+	}
+}
+
+func TestCommand_Timeout(t *testing.T) {
+	s := New()
+	j := s.ScheduleCtx(Command("sleep", "10"), Never, Immediately(), Timeout(10*time.Millisecond))
+	s.WaitForJobsToFinish()
+
+	status := j.History()[0]
+	if status.Error == nil || status.End.Sub(status.Start) > 5*time.Second {
+		t.Error("The command should be killed after the timeout")
+	}
+	expectInt(t, status.ExitCode, -1)
+}
+
+func TestShell_TimeoutChildren(t *testing.T) {
+	// The shell's children are killed with it
+	s := New()
+	j := s.ScheduleCtx(Shell("sleep 3; echo hi"), Never, Immediately(), Timeout(300*time.Millisecond))
+	s.WaitForJobsToFinish()
+
+	status := j.History()[0]
+	if status.Error == nil || status.End.Sub(status.Start) > 2*time.Second {
+		t.Errorf("The shell and its children should be killed after the timeout: %s", status)
+	}
+	expectString(t, status.Stdout, "")
+}
+
+func TestTail(t *testing.T) {
+	out := &tail{max: 5}
+	out.Write([]byte("abc"))
+	out.Write([]byte("defg"))
+	expectString(t, out.String(), "cdefg")
+	out.Write([]byte(strings.Repeat("x", 10)))
+	expectString(t, out.String(), "xxxxx")
+}
@@ -0,0 +1,492 @@
+package schedule
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JobConfig is the declarative configuration of a single job. Exactly one of
+// Cron, Clock, or Every must be given. Weekdays restrict clocks to the given
+// days. Durations are parsed by time.ParseDuration and clocks are given as
+// "15:04:05". Jobs run the shell Command if one is given, otherwise the
+// function registered under Func, or under their name if Func is empty.
+type JobConfig struct {
+	Name     string   `json:"-"`
+	Func     string   `json:"func,omitempty"`
+	Command  string   `json:"command,omitempty"`
+	Cron     string   `json:"cron,omitempty"`
+	Clock    []string `json:"clock,omitempty"`
+	Weekdays []string `json:"weekdays,omitempty"`
+	Every    string   `json:"every,omitempty"`
+	Timeout  string   `json:"timeout,omitempty"`
+	Retries  int      `json:"retries,omitempty"`
+	Jitter   string   `json:"jitter,omitempty"`
+	Timezone string   `json:"timezone,omitempty"`
+	Enabled  *bool    `json:"enabled,omitempty"`
+
+	// Line is the line of the configuration file the job was declared on
+	Line int `json:"-"`
+}
+
+// Config is a declarative configuration of jobs, in the order they were
+// declared.
+type Config struct {
+	Jobs []JobConfig
+}
+
+// ConfigError is an error in the configuration of a job.
+type ConfigError struct {
+	Line int
+	Job  string
+	Err  error
+}
+
+// Error returns the error prefixed with its line and job, such as
+// "line 3: backup: unknown weekday".
+func (e *ConfigError) Error() string {
+	if e.Job == "" {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+	}
+	return fmt.Sprintf("line %d: %s: %s", e.Line, e.Job, e.Err)
+}
+
+// ConfigErrors are all of the errors found while validating a configuration.
+type ConfigErrors []*ConfigError
+
+// Error returns every error on its own line.
+func (es ConfigErrors) Error() string {
+	lines := make([]string, len(es))
+	for i, e := range es {
+		lines[i] = e.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// IsEnabled returns true unless the job was explicitly disabled.
+func (jc JobConfig) IsEnabled() bool {
+	return jc.Enabled == nil || *jc.Enabled
+}
+
+// FuncName returns the name of the function the job runs.
+func (jc JobConfig) FuncName() string {
+	if jc.Func == "" {
+		return jc.Name
+	}
+	return jc.Func
+}
+
+// parseDuration parses an optional duration, which must not be negative.
+func parseDuration(field, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %s", field, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("%s must not be negative", field)
+	}
+	return d, nil
+}
+
+// Schedule builds the Schedule of the job.
+func (jc JobConfig) Schedule() (Schedule, error) {
+	kinds := 0
+	for _, given := range []bool{jc.Cron != "", len(jc.Clock) > 0, jc.Every != ""} {
+		if given {
+			kinds += 1
+		}
+	}
+	if kinds != 1 {
+		return nil, fmt.Errorf("exactly one of cron, clock, or every must be given")
+	}
+	if len(jc.Weekdays) > 0 && len(jc.Clock) == 0 {
+		return nil, fmt.Errorf("weekdays may only be given with clock")
+	}
+
+	loc := time.Local
+	if jc.Timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(jc.Timezone); err != nil {
+			return nil, fmt.Errorf("unknown timezone %q", jc.Timezone)
+		}
+	}
+
+	switch {
+	case jc.Cron != "":
+		return ParseCronIn(jc.Cron, loc)
+	case jc.Every != "":
+		d, err := parseDuration("every", jc.Every)
+		if err != nil {
+			return nil, err
+		}
+		if d == 0 {
+			return nil, fmt.Errorf("every must be positive")
+		}
+		return Interval(d), nil
+	}
+
+	clocks := make(Clocks, len(jc.Clock))
+	for i, value := range jc.Clock {
+		clock, err := ParseClockIn(value, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid clock %q", value)
+		}
+		clocks[i] = clock
+	}
+	SortClocks(clocks)
+	if len(jc.Weekdays) == 0 {
+		return clocks, nil
+	}
+	days := make([]time.Weekday, len(jc.Weekdays))
+	for i, value := range jc.Weekdays {
+		day, err := ParseWeekday(value)
+		if err != nil {
+			return nil, fmt.Errorf("unknown weekday %q", value)
+		}
+		days[i] = day
+	}
+	return DayClocks{uniqueDays(sortedWeekdays(days)), clocks}, nil
+}
+
+// Options builds the options of the job, including its name.
+func (jc JobConfig) Options() ([]Option, error) {
+	opts := []Option{Named(jc.Name)}
+	timeout, err := parseDuration("timeout", jc.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	if timeout > 0 {
+		opts = append(opts, Timeout(timeout))
+	}
+	jitter, err := parseDuration("jitter", jc.Jitter)
+	if err != nil {
+		return nil, err
+	}
+	if jitter > 0 {
+		opts = append(opts, Jitter(jitter))
+	}
+	if jc.Retries < 0 {
+		return nil, fmt.Errorf("retries must not be negative")
+	}
+	if jc.Retries > 0 {
+		opts = append(opts, Retries(jc.Retries))
+	}
+	return opts, nil
+}
+
+// Validate returns the errors of every job in the configuration, or nil if
+// the configuration is valid.
+func (c *Config) Validate() error {
+	var errs ConfigErrors
+	seen := make(map[string]bool)
+	for _, jc := range c.Jobs {
+		fail := func(err error) {
+			errs = append(errs, &ConfigError{Line: jc.Line, Job: jc.Name, Err: err})
+		}
+		if jc.Name == "" {
+			fail(fmt.Errorf("jobs must have a name"))
+			continue
+		}
+		if seen[jc.Name] {
+			fail(fmt.Errorf("duplicate job"))
+			continue
+		}
+		seen[jc.Name] = true
+		if _, err := jc.Schedule(); err != nil {
+			fail(err)
+		}
+		if _, err := jc.Options(); err != nil {
+			fail(err)
+		}
+		if jc.Command != "" && jc.Func != "" {
+			fail(fmt.Errorf("only one of func or command may be given"))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// lineAt returns the line number of the given offset within the data.
+func lineAt(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+// ParseJSONConfig parses a JSON object mapping job names to their
+// configuration, such as:
+//
+//	{
+//	  "backup": {"cron": "30 1 * * *", "timezone": "America/Denver", "retries": 2},
+//	  "sync":   {"every": "5m", "jitter": "30s", "timeout": "1m"},
+//	  "report": {"clock": ["9:00:00"], "weekdays": ["mon", "fri"], "enabled": false}
+//	}
+//
+// The configuration is not validated.
+func ParseJSONConfig(r io.Reader) (*Config, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	syntaxError := func(err error) error {
+		if se, ok := err.(*json.SyntaxError); ok {
+			return &ConfigError{Line: lineAt(data, se.Offset), Err: err}
+		}
+		return &ConfigError{Line: lineAt(data, dec.InputOffset()), Err: err}
+	}
+
+	if tok, err := dec.Token(); err != nil {
+		return nil, syntaxError(err)
+	} else if tok != json.Delim('{') {
+		return nil, &ConfigError{Line: 1, Err: fmt.Errorf("the configuration must be an object of jobs")}
+	}
+
+	config := &Config{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, syntaxError(err)
+		}
+		name := tok.(string)
+		line := lineAt(data, dec.InputOffset())
+
+		jc := JobConfig{Name: name, Line: line}
+		if err := dec.Decode(&jc); err != nil {
+			if _, ok := err.(*json.SyntaxError); ok {
+				return nil, syntaxError(err)
+			}
+			return nil, &ConfigError{Line: line, Job: name, Err: err}
+		}
+		// Decoding replaces the name and line
+		jc.Name, jc.Line = name, line
+		config.Jobs = append(config.Jobs, jc)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, syntaxError(err)
+	}
+	return config, nil
+}
+
+// ParseTextConfig parses a line-oriented configuration of jobs. Each line is
+// a job name, a schedule of cron, clock, or every followed by its value, and
+// then any options as key=value pairs. Lists are separated by commas. A shell
+// command may follow a "--" at the end of the line. Blank lines and lines
+// starting with # are ignored:
+//
+//	# name  schedule             options
+//	backup  cron 30 1 * * *      timezone=America/Denver retries=2
+//	sync    every 5m             jitter=30s timeout=1m
+//	report  clock 9:00:00        weekdays=mon,fri enabled=false
+//	clean   every 1h             timeout=5m -- find /tmp -mtime +7 -delete
+//
+// The configuration is not validated.
+func ParseTextConfig(r io.Reader) (*Config, error) {
+	config := &Config{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line += 1 {
+		text := scanner.Text()
+		var command string
+		if i := strings.Index(text, " -- "); i >= 0 {
+			text, command = text[:i], strings.TrimSpace(text[i+4:])
+		}
+		fields := strings.Fields(text)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		jc := JobConfig{Name: fields[0], Command: command, Line: line}
+		fail := func(format string, args ...interface{}) error {
+			return &ConfigError{Line: line, Job: jc.Name, Err: fmt.Errorf(format, args...)}
+		}
+		if len(fields) < 3 {
+			return nil, fail("a schedule and its value must be given")
+		}
+
+		// The value of the schedule is every field before the options
+		kind := fields[1]
+		var values []string
+		rest := fields[2:]
+		for len(rest) > 0 && !strings.Contains(rest[0], "=") {
+			values = append(values, rest[0])
+			rest = rest[1:]
+		}
+		if len(values) == 0 {
+			return nil, fail("%s must have a value", kind)
+		}
+		switch kind {
+		case "cron":
+			jc.Cron = strings.Join(values, " ")
+		case "clock":
+			jc.Clock = strings.Split(strings.Join(values, ","), ",")
+		case "every":
+			jc.Every = strings.Join(values, " ")
+		default:
+			return nil, fail("unknown schedule %q", kind)
+		}
+
+		for _, option := range rest {
+			i := strings.Index(option, "=")
+			if i < 0 {
+				return nil, fail("option %q must be key=value", option)
+			}
+			key, value := option[:i], option[i+1:]
+			switch key {
+			case "func":
+				jc.Func = value
+			case "weekdays":
+				jc.Weekdays = strings.Split(value, ",")
+			case "timeout":
+				jc.Timeout = value
+			case "jitter":
+				jc.Jitter = value
+			case "timezone":
+				jc.Timezone = value
+			case "retries":
+				n, err := strconv.Atoi(value)
+				if err != nil {
+					return nil, fail("invalid retries %q", value)
+				}
+				jc.Retries = n
+			case "enabled":
+				enabled, err := strconv.ParseBool(value)
+				if err != nil {
+					return nil, fail("invalid enabled %q", value)
+				}
+				jc.Enabled = &enabled
+			default:
+				return nil, fail("unknown option %q", key)
+			}
+		}
+		config.Jobs = append(config.Jobs, jc)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// LoadConfig reads and validates the configuration file at the given path.
+// Files ending in .json are parsed as JSON, and all others as text.
+func LoadConfig(path string) (*Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var config *Config
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		config, err = ParseJSONConfig(f)
+	} else {
+		config, err = ParseTextConfig(f)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Register makes the function available to configured jobs under the given
+// name.
+func (s *Scheduler) Register(name string, exec func() error) {
+	s.RegisterCtx(name, niladic(exec))
+}
+
+// RegisterCtx makes the context-aware function available to configured jobs
+// under the given name.
+func (s *Scheduler) RegisterCtx(name string, exec JobFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.funcs == nil {
+		s.funcs = make(map[string]JobFunc)
+	}
+	s.funcs[name] = exec
+}
+
+// registered returns the function registered under the given name.
+func (s *Scheduler) registered(name string) (JobFunc, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	exec, ok := s.funcs[name]
+	return exec, ok
+}
+
+// checkFuncs returns an error for every enabled job of the configuration
+// whose function is not registered.
+func (s *Scheduler) checkFuncs(c *Config) error {
+	var errs ConfigErrors
+	for _, jc := range c.Jobs {
+		if jc.Command != "" || !jc.IsEnabled() {
+			continue
+		}
+		if _, ok := s.registered(jc.FuncName()); !ok {
+			errs = append(errs, &ConfigError{
+				Line: jc.Line,
+				Job:  jc.Name,
+				Err:  fmt.Errorf("no function is registered as %q", jc.FuncName()),
+			})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// start starts the configured job. The configuration must be valid and its
+// function registered.
+func (s *Scheduler) start(jc JobConfig) *Job {
+	exec, _ := s.registered(jc.FuncName())
+	if jc.Command != "" {
+		exec = Shell(jc.Command)
+	}
+	schedule, _ := jc.Schedule()
+	opts, _ := jc.Options()
+	return s.ScheduleCtx(exec, schedule, opts...)
+}
+
+// Load starts a job for every enabled job of the configuration. The
+// configuration is validated and every job's function must be registered
+// before any job is started.
+func (s *Scheduler) Load(c *Config) ([]*Job, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkFuncs(c); err != nil {
+		return nil, err
+	}
+	var jobs []*Job
+	for _, jc := range c.Jobs {
+		if jc.IsEnabled() {
+			jobs = append(jobs, s.start(jc))
+		}
+	}
+	return jobs, nil
+}
+
+// LoadFile reads the configuration file at the given path and starts its
+// jobs.
+func (s *Scheduler) LoadFile(path string) ([]*Job, error) {
+	c, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	return s.Load(c)
+}
@@ -0,0 +1,217 @@
+package schedule
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testJSONConfig = `{
+  "backup": {"cron": "30 1 * * *", "timezone": "America/Denver", "retries": 2},
+  "sync": {"every": "5m", "jitter": "30s", "timeout": "1m", "func": "syncAll"},
+  "report": {"clock": ["17:00:00", "9:00:00"], "weekdays": ["fri", "mon"], "enabled": false}
+}`
+
+const testTextConfig = `# name  schedule          options
+backup  cron 30 1 * * *   timezone=America/Denver retries=2
+
+sync    every 5m          jitter=30s timeout=1m func=syncAll
+report  clock 17:00:00,9:00:00 weekdays=fri,mon enabled=false
+`
+
+// expectConfig checks the parsed test configuration and the lines its jobs
+// were declared on.
+func expectConfig(t *testing.T, c *Config, lines ...int) {
+	expectInt(t, len(c.Jobs), 3)
+	for i, line := range lines {
+		expectInt(t, c.Jobs[i].Line, line)
+	}
+	expectString(t, c.Jobs[0].Name, "backup")
+	expectString(t, c.Jobs[0].Cron, "30 1 * * *")
+	expectString(t, c.Jobs[0].Timezone, "America/Denver")
+	expectInt(t, c.Jobs[0].Retries, 2)
+
+	expectString(t, c.Jobs[1].FuncName(), "syncAll")
+	expectString(t, c.Jobs[1].Every, "5m")
+	expectString(t, c.Jobs[1].Jitter, "30s")
+	expectString(t, c.Jobs[1].Timeout, "1m")
+
+	if c.Jobs[2].IsEnabled() || !c.Jobs[0].IsEnabled() {
+		t.Error("Only the report should be disabled")
+	}
+	schedule, err := c.Jobs[2].Schedule()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectString(t, Describe(schedule), "Mon and Fri at 9:00 and 17:00")
+}
+
+func TestParseJSONConfig(t *testing.T) {
+	loadDenver(t)
+	c, err := ParseJSONConfig(strings.NewReader(testJSONConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectConfig(t, c, 2, 3, 4)
+	if err := c.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Errors point at the line of the job
+	_, err = ParseJSONConfig(strings.NewReader("{\n\"a\": {\"every\": \"5m\"},\n\"b\": {\"evry\": \"5m\"}\n}"))
+	if err == nil || !strings.HasPrefix(err.Error(), "line 3: b: ") {
+		t.Errorf("Unexpected error for an unknown field: %v", err)
+	}
+	_, err = ParseJSONConfig(strings.NewReader("{\n\"a\": {\"every\": \"5m\"},\n\"b\": {\"every\" \"5m\"}\n}"))
+	if err == nil || !strings.HasPrefix(err.Error(), "line 3: ") {
+		t.Errorf("Unexpected error for invalid syntax: %v", err)
+	}
+	if _, err := ParseJSONConfig(strings.NewReader(`[]`)); err == nil {
+		t.Error("The configuration must be an object")
+	}
+}
+
+func TestParseTextConfig(t *testing.T) {
+	loadDenver(t)
+	c, err := ParseTextConfig(strings.NewReader(testTextConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectConfig(t, c, 2, 4, 5)
+	if err := c.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	invalid := map[string]string{
+		"a every 5m\nb hourly 5":       "line 2: b: unknown schedule \"hourly\"",
+		"a every 5m\n\nb every 5m x=1": "line 3: b: unknown option \"x\"",
+		"a every":                      "line 1: a: a schedule and its value must be given",
+		"a every timeout=5m":           "line 1: a: every must have a value",
+		"a every 5m retries=many":      "line 1: a: invalid retries \"many\"",
+		"a every 5m enabled=sometimes": "line 1: a: invalid enabled \"sometimes\"",
+		"a every 5m timeout=1m extra":  "line 1: a: option \"extra\" must be key=value",
+	}
+	for text, expected := range invalid {
+		_, err := ParseTextConfig(strings.NewReader(text))
+		if err == nil {
+			t.Errorf("Expected an error parsing %q", text)
+			continue
+		}
+		expectString(t, err.Error(), expected)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	text := `a every 5m
+b every 5m weekdays=mon
+c clock 25:00:00
+d cron 60 * * * *
+e every -5m
+f every 5m timeout=soon
+a every 1h
+g clock 9:00:00 timezone=Nowhere/Special
+h every 5m retries=-1
+`
+	c, err := ParseTextConfig(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.Validate()
+	errs, ok := err.(ConfigErrors)
+	if !ok {
+		t.Fatalf("Expected configuration errors, got %v", err)
+	}
+	lines := make([]int, len(errs))
+	for i, e := range errs {
+		lines[i] = e.Line
+	}
+	expectInt(t, len(lines), 8)
+	for i, line := range []int{2, 3, 4, 5, 6, 7, 8, 9} {
+		expectInt(t, lines[i], line)
+	}
+	expectString(t, errs[6].Error(), "line 8: g: unknown timezone \"Nowhere/Special\"")
+
+	// Only one schedule may be given
+	both := &Config{Jobs: []JobConfig{{Name: "a", Every: "5m", Cron: "* * * * *", Line: 1}}}
+	if err := both.Validate(); err == nil {
+		t.Error("A job with two schedules should be invalid")
+	}
+}
+
+func TestScheduler_Load(t *testing.T) {
+	s := New()
+	c, err := ParseTextConfig(strings.NewReader("a every 1h\nb every 1h func=shared\nc every 1h enabled=false"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every enabled job must have a registered function
+	_, err = s.Load(c)
+	if err == nil {
+		t.Fatal("Jobs without registered functions should not be loaded")
+	}
+	expectString(t, err.Error(), "line 1: a: no function is registered as \"a\"\nline 2: b: no function is registered as \"shared\"")
+	expectInt(t, len(s.Jobs()), 0)
+
+	noop := func() error { return nil }
+	s.Register("a", noop)
+	s.Register("shared", noop)
+	jobs, err := s.Load(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectInt(t, len(jobs), 2)
+	expectString(t, jobs[0].Name, "a")
+	expectString(t, jobs[1].Name, "b")
+	expectString(t, jobs[1].description(), "every hour")
+	for _, job := range jobs {
+		job.Quit()
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	loadDenver(t)
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "jobs.json")
+	textPath := filepath.Join(dir, "jobs.conf")
+	if err := os.WriteFile(jsonPath, []byte(testJSONConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(textPath, []byte(testTextConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{jsonPath, textPath} {
+		c, err := LoadConfig(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectInt(t, len(c.Jobs), 3)
+	}
+	if _, err := LoadConfig(filepath.Join(dir, "missing.conf")); err == nil {
+		t.Error("Expected an error loading a missing file")
+	}
+}
+
+func TestParseTextConfig_Command(t *testing.T) {
+	c, err := ParseTextConfig(strings.NewReader("clean every 1h timeout=5m -- find /tmp  -mtime +7 -delete\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectString(t, c.Jobs[0].Command, "find /tmp  -mtime +7 -delete")
+	expectString(t, c.Jobs[0].Timeout, "5m")
+
+	// Jobs with commands do not need a registered function
+	s := New()
+	jobs, err := s.Load(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobs[0].Quit()
+
+	c.Jobs[0].Func = "clean"
+	if err := c.Validate(); err == nil {
+		t.Error("A job with both a function and a command should be invalid")
+	}
+}
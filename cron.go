@@ -0,0 +1,417 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchYears is how far ahead a cron expression is searched for its
+// next occurrence. Expressions such as February 30th never occur.
+const cronSearchYears = 5
+
+// cronMacros are the shorthand expressions accepted in place of five fields.
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronField describes the range and names of a field of a cron expression.
+type cronField struct {
+	name     string
+	min, max int
+	names    []string // Names of the values starting at min
+}
+
+var (
+	cronMinute = cronField{"minute", 0, 59, nil}
+	cronHour   = cronField{"hour", 0, 23, nil}
+	cronDom    = cronField{"day of month", 1, 31, nil}
+	cronMonth  = cronField{"month", 1, 12, []string{
+		"jan", "feb", "mar", "apr", "may", "jun",
+		"jul", "aug", "sep", "oct", "nov", "dec",
+	}}
+	// Sunday may be given as either 0 or 7
+	cronDow = cronField{"day of week", 0, 7, []string{
+		"sun", "mon", "tue", "wed", "thu", "fri", "sat",
+	}}
+)
+
+// Cron is a Schedule parsed from a standard five field cron expression of
+// minute, hour, day of month, month, and day of week. Fields may be lists,
+// ranges, and steps, such as "0,30 9-17 * * mon-fri" or "*/15 * * * *".
+// If both the day of month and day of week are restricted, either matching
+// is an occurrence. The macros @yearly, @monthly, @weekly, @daily, and
+// @hourly are also accepted.
+type Cron struct {
+	expr    string
+	minute  uint64
+	hour    uint64
+	dom     uint64
+	month   uint64
+	dow     uint64
+	domStar bool
+	dowStar bool
+	loc     *time.Location
+}
+
+// ParseCron parses the cron expression in the local timezone.
+func ParseCron(expr string) (Cron, error) {
+	return ParseCronIn(expr, time.Local)
+}
+
+// ParseCronIn parses the cron expression in the given location.
+func ParseCronIn(expr string, loc *time.Location) (Cron, error) {
+	c := Cron{expr: strings.TrimSpace(expr), loc: loc}
+	spec := c.expr
+	if macro, ok := cronMacros[strings.ToLower(spec)]; ok {
+		spec = macro
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return Cron{}, fmt.Errorf("schedule: cron expression %q must have five fields", expr)
+	}
+
+	var err error
+	if c.minute, err = cronMinute.parse(fields[0]); err != nil {
+		return Cron{}, err
+	}
+	if c.hour, err = cronHour.parse(fields[1]); err != nil {
+		return Cron{}, err
+	}
+	if c.dom, err = cronDom.parse(fields[2]); err != nil {
+		return Cron{}, err
+	}
+	if c.month, err = cronMonth.parse(fields[3]); err != nil {
+		return Cron{}, err
+	}
+	if c.dow, err = cronDow.parse(fields[4]); err != nil {
+		return Cron{}, err
+	}
+	// Fold Sunday as 7 into Sunday as 0
+	if c.dow&(1<<7) != 0 {
+		c.dow = c.dow&^(1<<7) | 1
+	}
+	c.domStar = strings.HasPrefix(fields[2], "*")
+	c.dowStar = strings.HasPrefix(fields[4], "*")
+	return c, nil
+}
+
+// MustParseCron parses the cron expression in the local timezone. It will
+// panic on error.
+func MustParseCron(expr string) Cron {
+	c, err := ParseCron(expr)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// parse parses a comma separated list of values, ranges, and steps into a
+// set of bits.
+func (f cronField) parse(value string) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(value, ",") {
+		start, end, step := f.min, f.max, 1
+
+		rng := item
+		if i := strings.Index(item, "/"); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(item[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("schedule: invalid step in cron %s %q", f.name, item)
+			}
+			rng = item[:i]
+		}
+
+		switch {
+		case rng == "*":
+		case strings.Contains(rng, "-"):
+			i := strings.Index(rng, "-")
+			var err error
+			if start, err = f.value(rng[:i]); err != nil {
+				return 0, err
+			}
+			if end, err = f.value(rng[i+1:]); err != nil {
+				return 0, err
+			}
+			if end < start {
+				return 0, fmt.Errorf("schedule: invalid range in cron %s %q", f.name, item)
+			}
+		default:
+			var err error
+			if start, err = f.value(rng); err != nil {
+				return 0, err
+			}
+			// A single value with a step continues until the maximum
+			if !strings.Contains(item, "/") {
+				end = start
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// value parses a single number or name within the field's range.
+func (f cronField) value(value string) (int, error) {
+	for i, name := range f.names {
+		if strings.ToLower(value) == name {
+			return f.min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("schedule: invalid cron %s %q", f.name, value)
+	}
+	return v, nil
+}
+
+func has(bits uint64, v int) bool {
+	return bits&(1<<uint(v)) != 0
+}
+
+// matchesDay returns true if the day of month or week of the time match.
+func (c Cron) matchesDay(t time.Time) bool {
+	dom := has(c.dom, t.Day())
+	dow := has(c.dow, int(t.Weekday()))
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// Next returns the first time strictly after the given time that matches
+// every field of the expression. It returns a zero time if there is no
+// occurrence within five years.
+func (c Cron) Next(after time.Time) time.Time {
+	loc := c.loc
+	if loc == nil {
+		loc = time.UTC
+	}
+	t := after.In(loc).Truncate(time.Minute).Add(time.Minute)
+	limit := t.Year() + cronSearchYears
+
+	for t.Year() <= limit {
+		if !has(c.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !c.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !has(c.hour, t.Hour()) {
+			// The next hour may not exist or may repeat when daylight
+			// saving time changes, so always move forward
+			next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			if !next.After(t) {
+				next = t.Add(time.Hour - time.Duration(t.Minute())*time.Minute)
+			}
+			t = next
+			continue
+		}
+		if !has(c.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// Prev returns the last time strictly before the given time that matches
+// every field of the expression. It returns a zero time if there is no
+// occurrence within five years. Like Next, the minutes of an hour that is
+// repeated when daylight saving time ends are matched twice.
+func (c Cron) Prev(before time.Time) time.Time {
+	loc := c.loc
+	if loc == nil {
+		loc = time.UTC
+	}
+	t := before.In(loc).Add(-time.Nanosecond).Truncate(time.Minute)
+	limit := t.Year() - cronSearchYears
+
+	for t.Year() >= limit {
+		if !has(c.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc).Add(-time.Minute)
+			continue
+		}
+		if !c.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc).Add(-time.Minute)
+			continue
+		}
+		if !has(c.hour, t.Hour()) {
+			// Move back by elapsed time, since the previous hour may not
+			// exist or may repeat when daylight saving time changes
+			t = t.Add(-time.Duration(t.Minute()+1) * time.Minute)
+			continue
+		}
+		if !has(c.minute, t.Minute()) {
+			t = t.Add(-time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// values returns the values of the set bits between min and max.
+func values(bits uint64, min, max int) []int {
+	var vs []int
+	for v := min; v <= max; v += 1 {
+		if has(bits, v) {
+			vs = append(vs, v)
+		}
+	}
+	return vs
+}
+
+// regularStep returns the interval between values that start at zero and
+// repeat regularly through the whole range, such as the minutes of "*/15".
+// It returns zero if the values are not a regular step.
+func regularStep(vs []int, size int) int {
+	if len(vs) < 2 || vs[0] != 0 {
+		return 0
+	}
+	return stepFrom(vs, size)
+}
+
+// stepFrom returns the interval between values that repeat regularly from
+// the first through the end of the range, such as the hours of "3/2". It
+// returns zero if the values are not a regular step.
+func stepFrom(vs []int, size int) int {
+	if len(vs) < 2 {
+		return 0
+	}
+	s := vs[1] - vs[0]
+	for i := 1; i < len(vs); i += 1 {
+		if vs[i]-vs[i-1] != s {
+			return 0
+		}
+	}
+	if vs[len(vs)-1]+s < size {
+		return 0
+	}
+	return s
+}
+
+// Expression returns the cron expression as it was given.
+func (c Cron) Expression() string {
+	return c.expr
+}
+
+// describeDays describes the days on which the expression occurs, such as
+// "every day", "Mon–Fri", or "on the 1st and 15th of every month".
+func (c Cron) describeDays() string {
+	doms := values(c.dom, 1, 31)
+	dows := values(c.dow, 0, 6)
+	months := values(c.month, 1, 12)
+
+	monthly := "every month"
+	if len(months) < 12 {
+		monthly = joinRanges(months, abbreviateMonth)
+	}
+	onDoms := fmt.Sprintf("on the %s of %s", joinRanges(doms, ordinal), monthly)
+	onDows := joinRanges(dows, abbreviateDay)
+	if len(months) < 12 {
+		onDows = fmt.Sprintf("%s in %s", onDows, monthly)
+	}
+
+	allDoms, allDows := len(doms) == 31, len(dows) == 7
+	switch {
+	case allDoms && allDows:
+		if len(months) < 12 {
+			return "every day in " + monthly
+		}
+		return "every day"
+	case allDoms:
+		return onDows
+	case allDows:
+		return onDoms
+	case c.domStar || c.dowStar:
+		// Both restricted fields must match
+		return fmt.Sprintf("%s if it is a %s", onDoms, joinRanges(dows, abbreviateDay))
+	}
+	return fmt.Sprintf("%s or %s", onDoms, onDows)
+}
+
+// Describe returns an English description of the expression, such as
+// "Mon–Fri at 1:30 AM" or "every 15 minutes during hours 9–17".
+func (c Cron) Describe(f HourFormat) string {
+	minutes := values(c.minute, 0, 59)
+	hours := values(c.hour, 0, 23)
+	days := c.describeDays()
+	allMinutes, allHours := len(minutes) == 60, len(hours) == 24
+
+	hourName := func(h int) string {
+		if f == Hour12 {
+			return strings.Replace(formatHourMinute(h, 0, 0, f), ":00", "", 1)
+		}
+		return fmt.Sprintf("%d", h)
+	}
+	during := " during hours " + joinRanges(hours, hourName)
+	if len(hours) == 1 {
+		during = " during hour " + hourName(hours[0])
+	}
+
+	// Explicit times of day follow the days
+	if !allMinutes && !allHours && len(minutes)*len(hours) <= 6 {
+		var clocks []Clock
+		for _, h := range hours {
+			for _, m := range minutes {
+				clocks = append(clocks, Clock{(h*60 + m) * 60, c.loc})
+			}
+		}
+		return fmt.Sprintf("%s at %s", days, describeClocks(clocks, f))
+	}
+
+	var desc string
+	switch n := regularStep(minutes, 60); {
+	case allMinutes:
+		desc = "every minute"
+	case n > 0:
+		desc = fmt.Sprintf("every %d minutes", n)
+	case len(minutes) == 1 && minutes[0] == 0:
+		desc = "every hour"
+		if h := stepFrom(hours, 24); h > 1 && (hours[0] == 0 || len(hours) > 2) {
+			desc = fmt.Sprintf("every %d hours", h)
+			if hours[0] != 0 {
+				desc += " from " + hourName(hours[0])
+			}
+		} else if !allHours {
+			desc = "every hour from " + joinRanges(hours, hourName)
+		}
+		during = ""
+	default:
+		items := make([]string, len(minutes))
+		for i, m := range minutes {
+			items[i] = fmt.Sprintf(":%02d", m)
+		}
+		desc = "every hour at " + joinList(items)
+	}
+	if !allHours {
+		desc += during
+	}
+	if days != "every day" {
+		desc += ", " + days
+	}
+	if zone := zoneName(c.loc); zone != "" && (!allHours || days != "every day") {
+		desc += " in " + zone
+	}
+	return desc
+}
+
+// String returns an English description on a 24-hour clock. The expression
+// itself is returned by Expression.
+func (c Cron) String() string {
+	return c.Describe(Hour24)
+}
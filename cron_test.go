@@ -0,0 +1,172 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	valid := []string{
+		"* * * * *",
+		"*/15 9-17 * * mon-fri",
+		"0,30 1 1,15 jan-jun/2 *",
+		"0 0 * * 7",
+		"@daily",
+		"@Hourly",
+	}
+	for _, expr := range valid {
+		if _, err := ParseCronIn(expr, time.UTC); err != nil {
+			t.Errorf("Unexpected error parsing %q: %s", expr, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"* * * foo *",
+		"@sometimes",
+	}
+	for _, expr := range invalid {
+		if _, err := ParseCronIn(expr, time.UTC); err == nil {
+			t.Errorf("Expected an error parsing %q", expr)
+		}
+	}
+}
+
+func TestCron_Next(t *testing.T) {
+	at := func(month time.Month, day, hour, min int) time.Time {
+		return time.Date(2014, month, day, hour, min, 0, 0, time.UTC)
+	}
+	cron := func(expr string) Cron {
+		c, err := ParseCronIn(expr, time.UTC)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	// February 14th, 2014 was a Friday
+	expectOccurrences(t, cron("*/20 9 * * *"), at(2, 14, 9, 10),
+		at(2, 14, 9, 20),
+		at(2, 14, 9, 40),
+		at(2, 15, 9, 0),
+	)
+	expectOccurrences(t, cron("30 1 * * mon-fri"), at(2, 14, 2, 0),
+		at(2, 17, 1, 30),
+		at(2, 18, 1, 30),
+	)
+	expectOccurrences(t, cron("@monthly"), at(2, 14, 0, 0),
+		at(3, 1, 0, 0),
+		at(4, 1, 0, 0),
+	)
+
+	// Days of the month and week are either matched when both are given
+	expectOccurrences(t, cron("0 12 1 * sun"), at(2, 14, 0, 0),
+		at(2, 16, 12, 0),
+		at(2, 23, 12, 0),
+		at(3, 1, 12, 0),
+		at(3, 2, 12, 0),
+	)
+
+	// Sunday may be given as 7
+	expectOccurrences(t, cron("0 0 * * 7"), at(2, 14, 0, 0), at(2, 16, 0, 0))
+
+	// Times between minutes are moved to the next minute
+	expectTime(t, cron("* * * * *").Next(at(2, 14, 0, 0).Add(time.Second)), at(2, 14, 0, 1))
+
+	// February 30th never occurs
+	if !cron("0 0 30 feb *").Next(at(2, 14, 0, 0)).IsZero() {
+		t.Error("An impossible expression should never occur")
+	}
+	expectString(t, cron("30 1 * * *").Expression(), "30 1 * * *")
+}
+
+func TestCron_Describe(t *testing.T) {
+	describe := func(expr string, loc *time.Location, f HourFormat) string {
+		c, err := ParseCronIn(expr, loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c.Describe(f)
+	}
+	expected := map[string]string{
+		"* * * * *":             "every minute",
+		"*/15 * * * *":          "every 15 minutes",
+		"*/15 9-17 * * mon-fri": "every 15 minutes during hours 9–17, Mon–Fri in UTC",
+		"0 * * * *":             "every hour",
+		"0 9-17 * * *":          "every hour from 9–17 in UTC",
+		"0 */2 * * *":           "every 2 hours in UTC",
+		"0 1/3 * * *":           "every 3 hours from 1 in UTC",
+		"5,35 * * * *":          "every hour at :05 and :35",
+		"30 1 * * *":            "every day at 1:30 UTC",
+		"0,30 1 * * sat,sun":    "Sun and Sat at 1:00 and 1:30 UTC",
+		"0 0 1,15 * *":          "on the 1st and 15th of every month at 0:00 UTC",
+		"0 0 1 jan-jun *":       "on the 1st of Jan–Jun at 0:00 UTC",
+		"0 12 * dec *":          "every day in Dec at 12:00 UTC",
+		"0 12 13 * fri":         "on the 13th of every month or Fri at 12:00 UTC",
+	}
+	for expr, e := range expected {
+		expectString(t, describe(expr, time.UTC, Hour24), e)
+	}
+	expectString(t, describe("30 13 * * mon-fri", time.UTC, Hour12), "Mon–Fri at 1:30 PM UTC")
+	expectString(t, describe("0 9-17 * * *", time.UTC, Hour12), "every hour from 9 AM–5 PM in UTC")
+	expectString(t, describe("0 1/2 * * *", time.UTC, Hour12), "every 2 hours from 1 AM in UTC")
+	expectString(t, describe("30 1 * * *", time.Local, Hour24), "every day at 1:30")
+}
+
+func TestCron_DST(t *testing.T) {
+	denver := loadDenver(t)
+	c, err := ParseCronIn("30 2 * * *", denver)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 2:30 does not exist on March 9th, 2014 in Denver
+	expectOccurrences(t, c, time.Date(2014, time.Month(3), 8, 12, 0, 0, 0, denver),
+		time.Date(2014, time.Month(3), 10, 2, 30, 0, 0, denver),
+	)
+
+	hourly, err := ParseCronIn("15 * * * *", denver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectOccurrences(t, hourly, time.Date(2014, time.Month(3), 9, 1, 20, 0, 0, denver),
+		time.Date(2014, time.Month(3), 9, 3, 15, 0, 0, denver),
+	)
+}
+
+func TestCron_Prev(t *testing.T) {
+	denver := loadDenver(t)
+	c, err := ParseCronIn("30 2 * * *", denver)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 2:30 does not exist on March 9th, 2014 in Denver
+	prev := c.Prev(time.Date(2014, time.Month(3), 10, 2, 0, 0, 0, denver))
+	expectTime(t, prev, time.Date(2014, time.Month(3), 8, 2, 30, 0, 0, denver))
+
+	// Times between minutes are moved to the previous minute
+	every, err := ParseCronIn("*/15 9-17 * * mon-fri", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	monday := time.Date(2014, time.Month(2), 17, 9, 0, 0, 0, time.UTC)
+	expectTime(t, every.Prev(monday.Add(time.Second)), monday)
+	expectTime(t, every.Prev(monday), time.Date(2014, time.Month(2), 14, 17, 45, 0, 0, time.UTC))
+
+	impossible, err := ParseCronIn("0 0 30 feb *", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !impossible.Prev(monday).IsZero() {
+		t.Error("An impossible expression should never have occurred")
+	}
+}
@@ -0,0 +1,221 @@
+package schedule
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dashboardTemplate is the self-contained HTML page of the Dashboard. The
+// page refreshes itself every ten seconds.
+var dashboardTemplate = template.Must(template.New("dashboard").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="10">
+<title>Scheduled Jobs</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: 0.4em 0.8em; border-bottom: 1px solid #ddd; vertical-align: top; }
+ul { list-style: none; margin: 0; padding: 0; }
+form { display: inline; }
+.ok { color: #2a7d2a; }
+.error { color: #b22; }
+.muted { color: #888; }
+</style>
+</head>
+<body>
+<h1>Scheduled Jobs</h1>
+<p class="muted">Updated {{ .Now.Format "2006-01-02 15:04:05 MST" }}</p>
+{{ if .Jobs }}
+<table>
+<tr><th>ID</th><th>Name</th><th>Schedule</th><th>Next Run</th><th>Recent Runs</th><th></th></tr>
+{{ range .Jobs }}
+<tr>
+<td>{{ .ID }}</td>
+<td>{{ .Name }}</td>
+<td>{{ .Schedule }}{{ if .Paused }} <strong>(paused)</strong>{{ end }}</td>
+<td>{{ if .Next.IsZero }}<span class="muted">unknown</span>{{ else }}{{ .Next.Format "2006-01-02 15:04:05 MST" }}<br><span class="muted">in {{ .Countdown }}</span>{{ end }}</td>
+<td>
+{{ if .Statuses }}<ul>
+{{ range .Statuses }}<li>{{ .Start.Format "2006-01-02 15:04:05" }}
+{{ if .Skipped }}<span class="muted">SKIPPED</span>{{ else if .Error }}<span class="error">ERROR: {{ .Error }}</span>{{ else }}<span class="ok">OK</span>{{ end }}
+<span class="muted">({{ .Duration }})</span></li>
+{{ end }}</ul>{{ else }}<span class="muted">none</span>{{ end }}
+</td>
+<td>
+<form method="post" action="jobs/{{ .ID }}/run"><button type="submit">Run now</button></form>
+{{ if .Paused }}<form method="post" action="jobs/{{ .ID }}/resume"><button type="submit">Resume</button></form>
+{{ else }}<form method="post" action="jobs/{{ .ID }}/pause"><button type="submit">Pause</button></form>
+{{ end }}<form method="post" action="jobs/{{ .ID }}/quit"><button type="submit">Quit</button></form>
+</td>
+</tr>
+{{ end }}
+</table>
+{{ else }}
+<p>There are no running jobs.</p>
+{{ end }}
+</body>
+</html>
+`))
+
+// statusView is a Status as displayed on the Dashboard.
+type statusView struct {
+	Start    time.Time
+	Duration time.Duration
+	Error    string
+	Skipped  bool
+}
+
+// jobView is a Job as displayed on the Dashboard.
+type jobView struct {
+	ID        int
+	Name      string
+	Schedule  string
+	Paused    bool
+	Next      time.Time
+	Countdown time.Duration
+	Statuses  []statusView
+}
+
+// Dashboard is an http.Handler that serves an HTML page of the jobs on a
+// running Scheduler, with their recent statuses and buttons to run, pause,
+// resume, or quit them. It should be mounted with http.StripPrefix at a
+// path ending with a slash.
+type Dashboard struct {
+	// Authorize is an optional hook that is called before every request.
+	// If it returns an error, the request is rejected as forbidden.
+	Authorize func(*http.Request) error
+
+	// N is the number of recent statuses shown for each job.
+	N int
+
+	scheduler *Scheduler
+	now       func() time.Time
+}
+
+// view builds the display of a job. Statuses are shown most recent first.
+func (d *Dashboard) view(j *Job, now time.Time) jobView {
+	v := jobView{
+		ID:       j.ID(),
+		Name:     j.Name,
+		Schedule: j.description(),
+		Paused:   j.Paused(),
+		Next:     j.Next(),
+	}
+	if !v.Next.IsZero() && v.Next.After(now) {
+		v.Countdown = v.Next.Sub(now).Round(time.Second)
+	}
+	history := j.History()
+	for i := len(history) - 1; i >= 0 && len(v.Statuses) < d.N; i -= 1 {
+		s := history[i]
+		sv := statusView{
+			Start:    s.Start,
+			Duration: s.End.Sub(s.Start),
+			Skipped:  s.Skipped,
+		}
+		if s.Error != nil {
+			sv.Error = s.Error.Error()
+		}
+		v.Statuses = append(v.Statuses, sv)
+	}
+	return v
+}
+
+// ServeHTTP serves the dashboard page and handles its buttons.
+func (d *Dashboard) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if d.Authorize != nil {
+		if err := d.Authorize(r); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+	}
+
+	path := strings.Trim(r.URL.Path, "/")
+	if path == "" {
+		d.page(w, r)
+		return
+	}
+
+	// Buttons post to jobs/{id}/{action}
+	parts := strings.Split(path, "/")
+	if len(parts) != 3 || parts[0] != "jobs" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != "POST" {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	id, err := strconv.Atoi(parts[1])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	job := d.scheduler.Job(id)
+	if job == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch parts[2] {
+	case "run":
+		// The status will be shown on the page. The run is cancelled if the
+		// client disconnects.
+		if _, err := job.TriggerCtx(r.Context()); err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+	case "pause":
+		job.Pause()
+	case "resume":
+		job.Resume()
+	case "quit":
+		job.Quit()
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	// Return to the page at the root of the dashboard. The location is left
+	// relative since the dashboard does not know the prefix it is mounted at.
+	w.Header().Set("Location", "../../")
+	w.WriteHeader(http.StatusSeeOther)
+}
+
+// page renders the dashboard page.
+func (d *Dashboard) page(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	now := d.now()
+	jobs := d.scheduler.Jobs()
+	views := make([]jobView, len(jobs))
+	for i, job := range jobs {
+		views[i] = d.view(job, now)
+	}
+
+	// Render to a buffer so errors can still be reported
+	var buf bytes.Buffer
+	err := dashboardTemplate.Execute(&buf, struct {
+		Now  time.Time
+		Jobs []jobView
+	}{now, views})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	buf.WriteTo(w)
+}
+
+// NewDashboard creates a Dashboard for the given Scheduler that shows the
+// five most recent statuses of each job.
+func NewDashboard(s *Scheduler) *Dashboard {
+	return &Dashboard{N: 5, scheduler: s, now: defaultNow}
+}
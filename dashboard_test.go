@@ -0,0 +1,67 @@
+package schedule
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestDashboard(t *testing.T) {
+	s := New()
+	j := s.Daily(func() error { return errors.New("<failed>") }, MustParseClockUTC("3:00:00"))
+	dashboard := NewDashboard(s)
+	path := "/jobs/" + strconv.Itoa(j.ID())
+
+	// Run the job from the dashboard
+	w := httptest.NewRecorder()
+	dashboard.ServeHTTP(w, httptest.NewRequest("POST", path+"/run", nil))
+	expectInt(t, w.Code, 303)
+	expectString(t, w.Header().Get("Location"), "../../")
+
+	w = httptest.NewRecorder()
+	dashboard.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	expectInt(t, w.Code, 200)
+	body := w.Body.String()
+	expectContains(t, body, "every day at 3:00")
+	expectContains(t, body, "ERROR: &lt;failed&gt;")
+	expectContains(t, body, `action="jobs/`+strconv.Itoa(j.ID())+`/run"`)
+	expectContains(t, body, j.Next().Format("2006-01-02 15:04:05 MST"))
+
+	// Only GET is allowed on the page and POST on buttons
+	w = httptest.NewRecorder()
+	dashboard.ServeHTTP(w, httptest.NewRequest("GET", path+"/run", nil))
+	expectInt(t, w.Code, 405)
+	w = httptest.NewRecorder()
+	dashboard.ServeHTTP(w, httptest.NewRequest("POST", path+"/unknown", nil))
+	expectInt(t, w.Code, 404)
+
+	// Quit the job from the dashboard
+	w = httptest.NewRecorder()
+	dashboard.ServeHTTP(w, httptest.NewRequest("POST", path+"/quit", nil))
+	expectInt(t, w.Code, 303)
+	s.WaitForJobsToFinish()
+
+	w = httptest.NewRecorder()
+	dashboard.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	expectContains(t, w.Body.String(), "There are no running jobs.")
+}
+
+func TestDashboard_View(t *testing.T) {
+	now := time.Date(2014, time.Month(2), 14, 2, 59, 30, 0, time.UTC)
+	j := &Job{Name: "view", desc: [2]string{"every day at 3:00", "every day at 3:00 AM"}, next: now.Add(30 * time.Second)}
+	for i := 0; i < 3; i += 1 {
+		j.record(Status{Start: now.Add(time.Duration(i) * time.Second)})
+	}
+
+	d := &Dashboard{N: 2}
+	v := d.view(j, now)
+	if v.Countdown != 30*time.Second {
+		t.Errorf("Unexpected countdown: %s", v.Countdown)
+	}
+	if len(v.Statuses) != 2 {
+		t.Fatalf("Unexpected number of statuses: %d != 2", len(v.Statuses))
+	}
+	expectTime(t, v.Statuses[0].Start, now.Add(2*time.Second))
+}
@@ -1,6 +1,7 @@
 package schedule
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -21,9 +22,28 @@ func (d Daytime) Next() time.Time {
 }
 
 func (d Daytime) next(now func() time.Time) time.Time {
-	n := now()
-	// TODO If this clock has already occurred today, add a day
-	return n
+	return d.Schedule().Next(now())
+}
+
+// Prev returns the last occurrence of this day and clock strictly before the
+// given time.
+func (d Daytime) Prev(before time.Time) time.Time {
+	return DayClocks{[]time.Weekday{d.day}, []Clock{d.clock}}.Prev(before)
+}
+
+// Schedule returns a Schedule that occurs weekly on this day and clock.
+func (d Daytime) Schedule() Schedule {
+	return DayClocks{[]time.Weekday{d.day}, []Clock{d.clock}}
+}
+
+// Describe returns a description such as "Mon at 9:00 AM".
+func (d Daytime) Describe(f HourFormat) string {
+	return fmt.Sprintf("%s at %s", abbreviateDay(int(d.day)), describeClocks([]Clock{d.clock}, f))
+}
+
+// String returns a description on a 24-hour clock.
+func (d Daytime) String() string {
+	return d.Describe(Hour24)
 }
 
 // FromDayAndClock creates a Daytime from a given day of the week and time
@@ -0,0 +1,193 @@
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// HourFormat determines how clocks are written in descriptions.
+type HourFormat int
+
+const (
+	// Hour24 writes clocks on a 24-hour clock, such as "17:00".
+	Hour24 HourFormat = iota
+
+	// Hour12 writes clocks on a 12-hour clock, such as "5:00 PM".
+	Hour12
+)
+
+// Describer is implemented by schedules that can describe themselves with
+// either hour format.
+type Describer interface {
+	Describe(HourFormat) string
+}
+
+// DescribeWith returns a description of the given schedule with clocks
+// written in the given hour format.
+func DescribeWith(s Schedule, f HourFormat) string {
+	if d, ok := s.(Describer); ok {
+		return d.Describe(f)
+	}
+	return Describe(s)
+}
+
+// formatHourMinute writes a time of day. Seconds are only written if they
+// are not zero.
+func formatHourMinute(hour, minute, second int, f HourFormat) string {
+	var suffix string
+	if f == Hour12 {
+		suffix = " AM"
+		if hour >= 12 {
+			suffix = " PM"
+		}
+		if hour %= 12; hour == 0 {
+			hour = 12
+		}
+	}
+	if second != 0 {
+		return fmt.Sprintf("%d:%02d:%02d%s", hour, minute, second, suffix)
+	}
+	return fmt.Sprintf("%d:%02d%s", hour, minute, suffix)
+}
+
+// Format writes the clock in the given hour format, such as "9:00" or
+// "9:00 AM". Seconds are only written if they are not zero.
+func (c Clock) Format(f HourFormat) string {
+	hour, minute, second := c.HMS()
+	return formatHourMinute(hour%24, minute, second, f)
+}
+
+// zoneName returns the name of the location to be written after clocks, or
+// an empty string for the local timezone.
+func zoneName(loc *time.Location) string {
+	if loc == nil || loc == time.Local {
+		return ""
+	}
+	return loc.String()
+}
+
+// withZone appends the name of the location, if any, to the description.
+func withZone(desc string, loc *time.Location) string {
+	if zone := zoneName(loc); zone != "" {
+		return desc + " " + zone
+	}
+	return desc
+}
+
+// joinList joins the items as an English list, such as "a, b and c".
+func joinList(items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
+
+// joinRanges joins sorted values as an English list, writing runs of three or
+// more consecutive values as a range, such as "Mon–Fri and Sun".
+func joinRanges(values []int, name func(int) string) string {
+	var items []string
+	for i := 0; i < len(values); {
+		j := i
+		for j+1 < len(values) && values[j+1] == values[j]+1 {
+			j += 1
+		}
+		if j-i >= 2 {
+			items = append(items, name(values[i])+"–"+name(values[j]))
+		} else {
+			for k := i; k <= j; k += 1 {
+				items = append(items, name(values[k]))
+			}
+		}
+		i = j + 1
+	}
+	return joinList(items)
+}
+
+// abbreviateDay returns the three letter name of a weekday, such as "Mon".
+func abbreviateDay(d int) string {
+	return time.Weekday(d).String()[:3]
+}
+
+// abbreviateMonth returns the three letter name of a month, such as "Jan".
+func abbreviateMonth(m int) string {
+	return time.Month(m).String()[:3]
+}
+
+// ordinal returns the English ordinal of a number, such as "1st" or "22nd".
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
+
+// describeDays describes a set of weekdays, such as "Mon–Fri" or "every day".
+func describeDays(days []time.Weekday) string {
+	seen := make(map[time.Weekday]bool)
+	var values []int
+	for _, d := range sortedWeekdays(days) {
+		if !seen[d] {
+			seen[d] = true
+			values = append(values, int(d))
+		}
+	}
+	if len(values) == 7 {
+		return "every day"
+	}
+	return joinRanges(values, abbreviateDay)
+}
+
+// describeClocks describes a list of clocks, such as "9:00 and 17:00 UTC".
+// The location is written once if every clock shares it.
+func describeClocks(clocks []Clock, f HourFormat) string {
+	shared := true
+	for _, c := range clocks {
+		shared = shared && c.loc == clocks[0].loc
+	}
+	items := make([]string, len(clocks))
+	for i, c := range clocks {
+		items[i] = c.Format(f)
+		if !shared {
+			items[i] = withZone(items[i], c.loc)
+		}
+	}
+	desc := joinList(items)
+	if shared && len(clocks) > 0 {
+		desc = withZone(desc, clocks[0].loc)
+	}
+	return desc
+}
+
+// describeDuration describes a duration in whole units if possible, such as
+// "5 minutes" or "hour".
+func describeDuration(d time.Duration) string {
+	for _, unit := range []struct {
+		d    time.Duration
+		name string
+	}{
+		{24 * time.Hour, "day"},
+		{time.Hour, "hour"},
+		{time.Minute, "minute"},
+		{time.Second, "second"},
+	} {
+		if d%unit.d != 0 {
+			continue
+		}
+		if n := int64(d / unit.d); n != 1 {
+			return fmt.Sprintf("%d %ss", n, unit.name)
+		}
+		return unit.name
+	}
+	return d.String()
+}
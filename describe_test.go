@@ -0,0 +1,61 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDescribe(t *testing.T) {
+	threeAM := Clocks{MustParseClockUTC("3:00:00")}
+	expectString(t, DescribeWith(threeAM, Hour12), "every day at 3:00 AM UTC")
+	expectString(t, threeAM.String(), "every day at 3:00 UTC")
+
+	workweek := DayClocks{Workweek, []Clock{MustParseClock("9:00:00"), MustParseClock("17:00:00")}}
+	expectString(t, workweek.String(), "Mon–Fri at 9:00 and 17:00")
+	expectString(t, workweek.Describe(Hour12), "Mon–Fri at 9:00 AM and 5:00 PM")
+
+	// Days are sorted and runs of fewer than three days are listed
+	weekends := DayClocks{Weekends, []Clock{MustParseClockUTC("10:30:15")}}
+	expectString(t, weekends.String(), "Sun and Sat at 10:30:15 UTC")
+	expectString(t, DayClocks{}.String(), "never")
+
+	ticker := DaysAndClocksTicker(Workweek, []Clock{MustParseClockUTC("0:00:00")})
+	expectString(t, ticker.Describe(Hour12), "Mon–Fri at 12:00 AM UTC")
+
+	daytime := FromDayAndClock(time.Monday, MustParseClockUTC("9:00:00"))
+	expectString(t, daytime.Describe(Hour12), "Mon at 9:00 AM UTC")
+	expectString(t, daytime.String(), "Mon at 9:00 UTC")
+
+	expectString(t, Interval(5*time.Minute).String(), "every 5 minutes")
+	expectString(t, Interval(time.Hour).String(), "every hour")
+	expectString(t, Interval(90*time.Second).String(), "every 90 seconds")
+}
+
+func TestJob_Describe(t *testing.T) {
+	j := &Job{Name: "backup", schedule: Clocks{MustParseClockUTC("15:00:00")}}
+	j.setDescription()
+	expectString(t, j.Describe(Hour12), "every day at 3:00 PM UTC")
+	expectString(t, j.Describe(Hour24), "every day at 15:00 UTC")
+	expectString(t, j.String(), "backup: every day at 15:00 UTC")
+
+	j.Name = ""
+	expectString(t, j.String(), "every day at 15:00 UTC")
+}
+
+func TestOrdinal(t *testing.T) {
+	expected := map[int]string{
+		1: "1st", 2: "2nd", 3: "3rd", 4: "4th",
+		11: "11th", 12: "12th", 13: "13th",
+		21: "21st", 22: "22nd", 31: "31st",
+	}
+	for n, e := range expected {
+		expectString(t, ordinal(n), e)
+	}
+}
+
+func TestJoinRanges(t *testing.T) {
+	expectString(t, joinRanges(nil, ordinal), "")
+	expectString(t, joinRanges([]int{1}, ordinal), "1st")
+	expectString(t, joinRanges([]int{1, 2}, ordinal), "1st and 2nd")
+	expectString(t, joinRanges([]int{1, 2, 3, 5, 7, 8, 9, 10}, ordinal), "1st–3rd, 5th and 7th–10th")
+}
@@ -0,0 +1,153 @@
+package schedule
+
+import (
+	"sort"
+	"time"
+)
+
+// DefaultHistorySize is the number of recent statuses kept by every job
+// unless the HistorySize option is given.
+const DefaultHistorySize = 10
+
+// history is a ring buffer of a job's most recent statuses. Once full, each
+// new status overwrites the oldest.
+type history struct {
+	statuses []Status
+	start    int // Index of the oldest status once full
+	size     int // Zero for the default size
+}
+
+// capacity returns the maximum number of statuses kept.
+func (h *history) capacity() int {
+	if h.size < 1 {
+		return DefaultHistorySize
+	}
+	return h.size
+}
+
+// add records the status, replacing the oldest if the history is full.
+func (h *history) add(s Status) {
+	if len(h.statuses) < h.capacity() {
+		h.statuses = append(h.statuses, s)
+		return
+	}
+	h.statuses[h.start] = s
+	h.start = (h.start + 1) % len(h.statuses)
+}
+
+// list returns a copy of the statuses, oldest first.
+func (h *history) list() []Status {
+	out := make([]Status, 0, len(h.statuses))
+	out = append(out, h.statuses[h.start:]...)
+	return append(out, h.statuses[:h.start]...)
+}
+
+// resize changes the capacity, keeping the most recent statuses.
+func (h *history) resize(n int) {
+	statuses := h.list()
+	h.size = n
+	if extra := len(statuses) - h.capacity(); extra > 0 {
+		statuses = statuses[extra:]
+	}
+	h.statuses = statuses
+	h.start = 0
+}
+
+// HistorySize sets the number of recent statuses kept in the job's history.
+// Sizes less than one keep the default of DefaultHistorySize.
+func HistorySize(n int) Option {
+	return func(j *Job) {
+		j.history.resize(n)
+	}
+}
+
+// SetHistorySize changes the number of recent statuses kept in the job's
+// history. If the history shrinks, the oldest statuses are dropped.
+func (j *Job) SetHistorySize(n int) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.history.resize(n)
+}
+
+// History returns the statuses of the job's most recent runs, oldest first.
+func (j *Job) History() []Status {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.history.list()
+}
+
+// record adds the given status to the job's history.
+func (j *Job) record(s Status) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.history.add(s)
+}
+
+// Stats summarizes the runs in the job's history.
+func (j *Job) Stats() Stats {
+	return Summarize(j.History())
+}
+
+// Stats are aggregated from a list of statuses. Skipped runs are counted,
+// but are otherwise ignored.
+type Stats struct {
+	Runs     int
+	Skipped  int
+	Failures int
+
+	// SuccessRate is the fraction of runs without an error, between 0 and 1.
+	SuccessRate float64
+
+	// P50 and P95 are the median and 95th percentile durations of the runs.
+	P50 time.Duration
+	P95 time.Duration
+
+	// ConsecutiveFailures is the number of runs that have failed since the
+	// last successful run.
+	ConsecutiveFailures int
+}
+
+// Summarize aggregates the given statuses, which should be oldest first.
+func Summarize(statuses []Status) Stats {
+	var stats Stats
+	var durations []time.Duration
+	for _, s := range statuses {
+		if s.Skipped {
+			stats.Skipped += 1
+			continue
+		}
+		stats.Runs += 1
+		durations = append(durations, s.End.Sub(s.Start))
+		if s.Error != nil {
+			stats.Failures += 1
+			stats.ConsecutiveFailures += 1
+		} else {
+			stats.ConsecutiveFailures = 0
+		}
+	}
+	if stats.Runs == 0 {
+		return stats
+	}
+	stats.SuccessRate = float64(stats.Runs-stats.Failures) / float64(stats.Runs)
+
+	sort.Sort(durationSlice(durations))
+	stats.P50 = percentile(durations, 50)
+	stats.P95 = percentile(durations, 95)
+	return stats
+}
+
+// percentile returns the nearest-rank percentile of the sorted durations.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// durationSlice implements the sort.Interface for durations
+type durationSlice []time.Duration
+
+func (d durationSlice) Len() int           { return len(d) }
+func (d durationSlice) Swap(i, j int)      { d[i], d[j] = d[j], d[i] }
+func (d durationSlice) Less(i, j int) bool { return d[i] < d[j] }
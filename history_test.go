@@ -0,0 +1,92 @@
+package schedule
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// statusOf creates a status that ran for the given number of milliseconds.
+func statusOf(ms int, err error) Status {
+	start := time.Date(2014, time.Month(2), 14, 12, 0, 0, 0, time.UTC)
+	return Status{Start: start, End: start.Add(time.Duration(ms) * time.Millisecond), Error: err}
+}
+
+func expectDurations(t *testing.T, statuses []Status, ms ...int) {
+	if len(statuses) != len(ms) {
+		t.Fatalf("Unexpected number of statuses: %d != %d", len(statuses), len(ms))
+	}
+	for i, s := range statuses {
+		if d := s.End.Sub(s.Start); d != time.Duration(ms[i])*time.Millisecond {
+			t.Errorf("Unexpected duration of status %d: %s", i, d)
+		}
+	}
+}
+
+func TestHistory(t *testing.T) {
+	h := history{size: 3}
+	for i := 1; i <= 5; i += 1 {
+		h.add(statusOf(i, nil))
+	}
+	expectDurations(t, h.list(), 3, 4, 5)
+
+	// Growing keeps every status and shrinking keeps the most recent
+	h.resize(4)
+	h.add(statusOf(6, nil))
+	expectDurations(t, h.list(), 3, 4, 5, 6)
+	h.add(statusOf(7, nil))
+	expectDurations(t, h.list(), 4, 5, 6, 7)
+	h.resize(2)
+	expectDurations(t, h.list(), 6, 7)
+
+	// The zero value has the default size
+	var d history
+	for i := 0; i < 2*DefaultHistorySize; i += 1 {
+		d.add(statusOf(i, nil))
+	}
+	expectInt(t, len(d.list()), DefaultHistorySize)
+}
+
+func TestJob_History(t *testing.T) {
+	j, tick, runs := newPauseJob()
+	HistorySize(2)(j)
+	j.Run()
+	for i := 0; i < 3; i += 1 {
+		tick <- time.Now()
+		<-runs
+	}
+	j.Quit()
+	expectInt(t, len(j.History()), 2)
+
+	j.SetHistorySize(1)
+	expectInt(t, len(j.History()), 1)
+	expectInt(t, j.Stats().Runs, 1)
+}
+
+func TestSummarize(t *testing.T) {
+	failed := errors.New("failed")
+	statuses := []Status{
+		statusOf(40, failed),
+		{Skipped: true},
+	}
+	for i := 1; i <= 17; i += 1 {
+		statuses = append(statuses, statusOf(i, nil))
+	}
+	statuses = append(statuses, statusOf(100, failed), statusOf(50, failed))
+
+	stats := Summarize(statuses)
+	expectInt(t, stats.Runs, 20)
+	expectInt(t, stats.Skipped, 1)
+	expectInt(t, stats.Failures, 3)
+	expectInt(t, stats.ConsecutiveFailures, 2)
+	if stats.SuccessRate != 0.85 {
+		t.Errorf("Unexpected success rate: %f", stats.SuccessRate)
+	}
+	if stats.P50 != 10*time.Millisecond || stats.P95 != 50*time.Millisecond {
+		t.Errorf("Unexpected percentiles: %s, %s", stats.P50, stats.P95)
+	}
+
+	if empty := Summarize(nil); empty.Runs != 0 || empty.SuccessRate != 0 {
+		t.Errorf("Unexpected empty stats: %+v", empty)
+	}
+}
@@ -0,0 +1,70 @@
+package schedule
+
+// Middleware wraps a JobFunc with additional behavior. Middleware should call
+// the next JobFunc unless the run should be skipped.
+type Middleware func(next JobFunc) JobFunc
+
+// chain applies the middleware to the given JobFunc. The first middleware
+// is the outermost.
+func chain(exec JobFunc, mws []Middleware) JobFunc {
+	for i := len(mws) - 1; i >= 0; i -= 1 {
+		exec = mws[i](exec)
+	}
+	return exec
+}
+
+// Hooks are callbacks that are invoked during the lifecycle of a job. Any
+// nil callback is skipped. Hooks are called from the job's goroutine, so
+// long running hooks will delay the job's next iteration.
+type Hooks struct {
+	// BeforeRun is called before every run of the job.
+	BeforeRun func(*Job)
+
+	// AfterRun is called after every run of the job.
+	AfterRun func(*Job, Status)
+
+	// OnError is called after every run of the job that returned an error.
+	OnError func(*Job, Status)
+
+	// OnSuccess is called after every run of the job that did not return
+	// an error.
+	OnSuccess func(*Job, Status)
+
+	// OnQuit is called once the job stops running, either because it
+	// was quit or because it has completed all of its iterations.
+	OnQuit func(*Job)
+
+	// OnAlert is called when a Watchdog finds that the job is late or
+	// overrunning. It is called from the Watchdog's goroutine.
+	OnAlert func(*Job, Alert)
+}
+
+func (h Hooks) beforeRun(j *Job) {
+	if h.BeforeRun != nil {
+		h.BeforeRun(j)
+	}
+}
+
+func (h Hooks) afterRun(j *Job, s Status) {
+	if h.AfterRun != nil {
+		h.AfterRun(j, s)
+	}
+	if s.Error != nil && h.OnError != nil {
+		h.OnError(j, s)
+	}
+	if s.Error == nil && h.OnSuccess != nil {
+		h.OnSuccess(j, s)
+	}
+}
+
+func (h Hooks) onQuit(j *Job) {
+	if h.OnQuit != nil {
+		h.OnQuit(j)
+	}
+}
+
+func (h Hooks) onAlert(j *Job, a Alert) {
+	if h.OnAlert != nil {
+		h.OnAlert(j, a)
+	}
+}
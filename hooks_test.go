@@ -0,0 +1,92 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHooks(t *testing.T) {
+	var calls []string
+	record := func(name string) func(*Job, Status) {
+		return func(j *Job, s Status) { calls = append(calls, name) }
+	}
+	wrap := func(name string) Middleware {
+		return func(next JobFunc) JobFunc {
+			return func(ctx context.Context) error {
+				calls = append(calls, name)
+				return next(ctx)
+			}
+		}
+	}
+
+	s := New()
+	s.AddHooks(Hooks{
+		BeforeRun: func(j *Job) { calls = append(calls, "scheduler before") },
+		AfterRun:  record("scheduler after"),
+		OnQuit:    func(j *Job) { calls = append(calls, "scheduler quit") },
+	})
+	s.Use(wrap("scheduler outer"), wrap("scheduler inner"))
+
+	// Fail on the second run
+	var count int
+	tick := make(chan time.Time)
+	j := s.whenever(func(ctx context.Context) error {
+		count += 1
+		calls = append(calls, "exec")
+		if count == 2 {
+			return errors.New("failed")
+		}
+		return nil
+	}, tick)
+	j.AddHooks(Hooks{
+		BeforeRun: func(j *Job) { calls = append(calls, "job before") },
+		AfterRun:  record("job after"),
+		OnError:   record("job error"),
+		OnSuccess: record("job success"),
+		OnQuit:    func(j *Job) { calls = append(calls, "job quit") },
+	})
+	j.Use(wrap("job"))
+	j.Run()
+
+	tick <- time.Now()
+	tick <- time.Now()
+	j.Quit()
+	s.WaitForJobsToFinish()
+
+	run := []string{
+		"scheduler before",
+		"job before",
+		"scheduler outer",
+		"scheduler inner",
+		"job",
+		"exec",
+		"scheduler after",
+		"job after",
+	}
+	var expected []string
+	expected = append(expected, run...)
+	expected = append(expected, "job success")
+	expected = append(expected, run...)
+	expected = append(expected, "job error", "scheduler quit", "job quit")
+	expectString(t, strings.Join(calls, ", "), strings.Join(expected, ", "))
+}
+
+func TestMiddleware_Skip(t *testing.T) {
+	// Middleware may skip a run by not calling the next function
+	var ran bool
+	exec := chain(
+		func(ctx context.Context) error { ran = true; return nil },
+		[]Middleware{func(next JobFunc) JobFunc {
+			return func(ctx context.Context) error { return nil }
+		}},
+	)
+	if err := exec(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if ran {
+		t.Error("The job function should have been skipped")
+	}
+}
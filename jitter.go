@@ -0,0 +1,141 @@
+package schedule
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"os"
+	"time"
+)
+
+type offset struct {
+	schedule Schedule
+	d        time.Duration
+}
+
+// Offset returns a Schedule whose occurrences are those of the given
+// schedule shifted later by the given duration.
+func Offset(s Schedule, d time.Duration) Schedule {
+	return offset{s, d}
+}
+
+func (o offset) Next(after time.Time) time.Time {
+	t := o.schedule.Next(after.Add(-o.d))
+	if t.IsZero() {
+		return t
+	}
+	return t.Add(o.d)
+}
+
+func (o offset) relative() bool {
+	return isRelative(o.schedule)
+}
+
+func (o offset) Describe(f HourFormat) string {
+	return fmt.Sprintf("%s, offset by %s", DescribeWith(o.schedule, f), o.d)
+}
+
+func (o offset) String() string {
+	return o.Describe(Hour24)
+}
+
+// SplayOffset returns a deterministic duration in [0, max) derived from a
+// hash of the given host and job name. Hosts running the same job will
+// spread its runs across the duration, but each host will always run it at
+// the same offset.
+func SplayOffset(host, name string, max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	h.Write([]byte(host))
+	h.Write([]byte{0})
+	h.Write([]byte(name))
+	return time.Duration(h.Sum64() % uint64(max))
+}
+
+// hostname returns the name of the host, or an empty string if it cannot
+// be determined.
+func hostname() string {
+	host, _ := os.Hostname()
+	return host
+}
+
+// Splay delays every occurrence of the job's schedule by a deterministic
+// offset up to the given maximum, derived from the host and the job's name.
+// The maximum should be less than the time between occurrences. Since the
+// occurrences of relative schedules, such as those of Repeat, are measured
+// from the previous run, only their first run is delayed.
+func Splay(max time.Duration) Option {
+	return func(j *Job) {
+		j.splay = max
+	}
+}
+
+// Jitter delays every run of the job by a random duration chosen uniformly
+// up to the given maximum. Runs that start immediately are not delayed, and
+// runs within the job's active windows are not delayed past their end.
+func Jitter(max time.Duration) Option {
+	return func(j *Job) {
+		j.jitter = max
+	}
+}
+
+// RandSource sets the source of randomness used for the job's jitter. It
+// allows jitter to be reproduced in tests.
+func RandSource(src rand.Source) Option {
+	return func(j *Job) {
+		j.rand = rand.New(src)
+	}
+}
+
+// constrain applies the job's splay and active windows to the given
+// schedule. Windows apply to the splayed occurrences. The splay of a
+// relative schedule is kept as a delay of its first run instead.
+func (j *Job) constrain(s Schedule) Schedule {
+	if s == nil {
+		return s
+	}
+	if j.splay > 0 {
+		splay := SplayOffset(hostname(), j.Name, j.splay)
+		if isRelative(s) {
+			j.delay = splay
+		} else {
+			s = Offset(s, splay)
+		}
+	}
+	if len(j.windows) > 0 {
+		s = During(s, j.windows...)
+	}
+	return s
+}
+
+// delayed adds the delay of the job's first run to the given time, once.
+// It must be called from the job's iteration loop.
+func (j *Job) delayed(t time.Time) time.Time {
+	if j.delay <= 0 || t.IsZero() {
+		return t
+	}
+	t, j.delay = t.Add(j.delay), 0
+	return t
+}
+
+// jittered adds a random jitter to the given time, without moving it past
+// the end of the active window that contains it. It must be called from the
+// job's iteration loop.
+func (j *Job) jittered(t time.Time) time.Time {
+	if j.jitter <= 0 || t.IsZero() {
+		return t
+	}
+	max := j.jitter
+	if end := j.windows.EndOf(t); !end.IsZero() && end.Sub(t) < max {
+		max = end.Sub(t)
+	}
+	if max <= 0 {
+		return t
+	}
+	if j.rand == nil {
+		j.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return t.Add(time.Duration(j.rand.Int63n(int64(max))))
+}
@@ -0,0 +1,120 @@
+package schedule
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestOffset(t *testing.T) {
+	daily := Clocks{MustParseClockUTC("3:00:00")}
+	o := Offset(daily, 10*time.Minute)
+	now := time.Date(2014, time.Month(2), 14, 3, 5, 0, 0, time.UTC)
+
+	// The occurrence at 3:00 is shifted to 3:10, which is after now
+	expectTime(t, o.Next(now), time.Date(2014, time.Month(2), 14, 3, 10, 0, 0, time.UTC))
+	expectTime(t, o.Next(o.Next(now)), time.Date(2014, time.Month(2), 15, 3, 10, 0, 0, time.UTC))
+	if !Offset(Never, time.Minute).Next(now).IsZero() {
+		t.Error("An offset of never should never occur")
+	}
+}
+
+func TestSplayOffset(t *testing.T) {
+	max := time.Hour
+	a := SplayOffset("host-a", "backup", max)
+	if a < 0 || a >= max {
+		t.Errorf("Splay out of range: %s", a)
+	}
+	if a != SplayOffset("host-a", "backup", max) {
+		t.Error("Splay should be deterministic")
+	}
+	if a == SplayOffset("host-b", "backup", max) && a == SplayOffset("host-a", "restore", max) {
+		t.Error("Splay should differ by host or job name")
+	}
+	expectInt(t, int(SplayOffset("host-a", "backup", 0)), 0)
+}
+
+func TestJob_Splay(t *testing.T) {
+	s := New()
+	clock := MustParseClockUTC("3:00:00")
+	j := s.schedule(niladic(func() error { return nil }), Clocks{clock}, Splay(time.Hour), Named("backup"))
+
+	expected := Offset(Clocks{clock}, SplayOffset(hostname(), "backup", time.Hour))
+	now := time.Now()
+	expectTime(t, j.schedule.Next(now), expected.Next(now))
+}
+
+func TestJob_SplayEvery(t *testing.T) {
+	s := New()
+	splay := SplayOffset(hostname(), "backup", time.Hour)
+	before := time.Now()
+	j := s.Every(func() error { return nil }, time.Hour, Splay(time.Hour), Named("backup"))
+	defer j.Quit()
+
+	// The runs of Every are shifted by the splay, an hour apart
+	first := j.Next()
+	if diff := (first.Sub(before) - splay) % time.Hour; diff < 0 || diff > time.Second {
+		t.Errorf("Every was not splayed: %s after %s", first, before)
+	}
+	expectTime(t, j.schedule.Next(first), first.Add(time.Hour))
+}
+
+func TestJob_SplayRepeat(t *testing.T) {
+	s := New()
+	splay := SplayOffset(hostname(), "backup", time.Hour)
+	before := time.Now()
+	j := s.Repeat(func() error { return nil }, time.Hour, Splay(time.Hour), Named("backup"))
+	defer j.Quit()
+
+	// Only the first run of a relative schedule is delayed
+	if diff := j.Next().Sub(before) - splay; diff < 0 || diff > time.Second {
+		t.Errorf("Repeat was not splayed: %s after %s", j.Next(), before)
+	}
+	expectTime(t, j.schedule.Next(j.Next()), j.Next().Add(time.Hour))
+}
+
+func TestJob_JitterWindow(t *testing.T) {
+	s := New()
+	j := s.schedule(
+		niladic(func() error { return nil }),
+		Interval(time.Minute),
+		Jitter(time.Hour),
+		ActiveDuring(NewWindow(MustParseClockUTC("9:00:00"), MustParseClockUTC("10:00:00"))),
+	)
+
+	// Jitter does not move a run past the end of its window
+	end := time.Date(2014, time.Month(2), 14, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 10; i += 1 {
+		next := j.jittered(end.Add(-time.Minute))
+		if !next.Before(end) {
+			t.Errorf("Jitter moved the run outside its window: %s", next)
+		}
+	}
+}
+
+func TestJob_Jitter(t *testing.T) {
+	s := New()
+	newJob := func() *Job {
+		return s.schedule(
+			niladic(func() error { return nil }),
+			Interval(time.Hour),
+			Jitter(time.Minute),
+			RandSource(rand.NewSource(42)),
+		)
+	}
+
+	// Jobs with the same seed have the same jitter
+	a, b := newJob(), newJob()
+	for i := 0; i < 10; i += 1 {
+		before := time.Now()
+		a.advance()
+		b.advance()
+		offset := a.Next().Sub(before) - time.Hour
+		if offset < 0 || offset >= time.Minute+time.Second {
+			t.Errorf("Jitter out of range: %s", offset)
+		}
+		if diff := a.Next().Sub(b.Next()); diff < -time.Second || diff > time.Second {
+			t.Errorf("Seeded jitter should match: %s != %s", a.Next(), b.Next())
+		}
+	}
+}
@@ -1,64 +1,408 @@
 package schedule
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
 	"time"
 )
 
-// Job wraps a niladic function that will be performed on every tick for a
-// given number of iterations. The easiest way to create a job is through
-// the scheduler methods such as Daily and RepeatN.
+// JobFunc is a function performed by a job. The context of every run carries
+// the run's Span.
+type JobFunc func(ctx context.Context) error
+
+// ErrJobStopped is returned when an action requires a job that is no
+// longer running.
+var ErrJobStopped = errors.New("schedule: job is not running")
+
+// niladic converts a function without a context into a JobFunc.
+func niladic(exec func() error) JobFunc {
+	return func(ctx context.Context) error { return exec() }
+}
+
+// Job wraps a function that will be performed on every occurrence of its
+// Schedule, or on every tick of an external time channel. The easiest way to
+// create a job is through the scheduler methods such as Daily and RepeatN.
 type Job struct {
-	Name      string
-	exec      func() error
-	quit      chan bool
-	tick      <-chan time.Time
-	setter    func() <-chan time.Time
-	n         int
-	increment int
-	scheduler *Scheduler
-}
-
-// Run will start the job's iteration loop. The job will run on the next tick.
-// Jobs are repeated for as many iterations were specified unless the quit
-// signal is received. During a job's iteration, the job's parent scheduler
-// has its wait group incremented. The wait group is then decremented upon
-// completion of the iteration loop or receiving a quit signal.
+	Name        string
+	exec        JobFunc
+	quit        chan bool
+	tick        <-chan time.Time
+	schedule    Schedule // nil if ticked by an external channel
+	immediately bool
+	times       int
+	limited     bool
+	quitting    bool // Quit while waiting for a slot
+	splay       time.Duration
+	delay       time.Duration // Splay of a relative schedule's first run
+	jitter      time.Duration
+	rand        *rand.Rand
+	windows     Windows
+	group       string
+	priority    int
+	timeout     time.Duration
+	retries     int
+	expected    time.Duration
+	scheduler   *Scheduler
+	id          int
+	triggers    chan triggerRequest
+	schedules   chan scheduleRequest
+	resumed     chan struct{}
+	done        chan struct{}
+
+	mu            sync.Mutex
+	desc          [2]string // Descriptions of when the job runs by HourFormat
+	next          time.Time
+	started       time.Time // Start of the run in progress, if any
+	lastStart     time.Time // Start of the most recent run
+	paused        bool
+	missed        int
+	pausePolicy   PausePolicy
+	misfirePolicy MisfirePolicy
+	countTriggers bool
+	history       history
+	hooks         []Hooks
+	middleware    []Middleware
+}
+
+// ID returns the job's identifier, which is unique within its scheduler. It
+// is assigned once the job is run.
+func (j *Job) ID() int {
+	return j.id
+}
+
+// Running returns true if the job's iteration loop has started and not yet
+// stopped.
+func (j *Job) Running() bool {
+	if j.done == nil {
+		return false
+	}
+	select {
+	case <-j.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// AddHooks adds lifecycle hooks to the job. They are called after the hooks
+// of the job's scheduler.
+func (j *Job) AddHooks(h Hooks) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.hooks = append(j.hooks, h)
+}
+
+// Use adds middleware around the job's function. Job middleware is applied
+// inside the middleware of the job's scheduler.
+func (j *Job) Use(mws ...Middleware) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.middleware = append(j.middleware, mws...)
+}
+
+// lifecycle returns the hooks and middleware of both the job's scheduler and
+// the job itself.
+func (j *Job) lifecycle() ([]Hooks, []Middleware) {
+	hooks, mws := j.scheduler.lifecycle()
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	hooks = append(hooks, j.hooks...)
+	mws = append(mws, j.middleware...)
+	return hooks, mws
+}
+
+// Next returns the time of the job's next scheduled run. It will be zero if
+// the job is ticked by an external time channel.
+func (j *Job) Next() time.Time {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.next
+}
+
+// advance sets the job's tick channel to the next occurrence of its schedule
+// after the current time. It returns false if the job has no schedule or the
+// schedule has no next occurrence.
+func (j *Job) advance() bool {
+	if j.schedule == nil {
+		return false
+	}
+	return j.reset(j.jittered(j.delayed(j.schedule.Next(time.Now()))))
+}
+
+// reset sets the job's next run time and its tick channel. It returns false
+// if the next time is zero, in which case the job will never tick again.
+func (j *Job) reset(next time.Time) bool {
+	j.mu.Lock()
+	j.next = next
+	j.mu.Unlock()
+	j.scheduler.metrics.scheduled(j, next)
+	if next.IsZero() {
+		j.tick = nil
+		return false
+	}
+	j.tick = TickAt(next)
+	return true
+}
+
+// describe builds a description of when the job runs from its schedule and
+// options.
+func (j *Job) describe(f HourFormat) string {
+	if j.schedule == nil {
+		return "on every tick"
+	}
+	if j.schedule == Never && j.immediately {
+		return "once, immediately"
+	}
+	desc := DescribeWith(j.schedule, f)
+	if j.immediately {
+		desc = "immediately, then " + desc
+	}
+	if j.limited {
+		desc = fmt.Sprintf("%s, %d times", desc, j.times)
+	}
+	return desc
+}
+
+// setDescription describes the job's current schedule in every hour format.
+// It must be called before the job runs or from its iteration loop.
+func (j *Job) setDescription() {
+	desc := [2]string{j.describe(Hour24), j.describe(Hour12)}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.desc = desc
+}
+
+// description returns a description of when the job runs.
+func (j *Job) description() string {
+	return j.Describe(Hour24)
+}
+
+// Describe returns a description of when the job runs, such as
+// "every day at 3:00 AM UTC".
+func (j *Job) Describe(f HourFormat) string {
+	if f != Hour12 {
+		f = Hour24
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.desc[f]
+}
+
+// String returns the name of the job and when it runs, such as
+// "backup: every day at 3:00 UTC".
+func (j *Job) String() string {
+	if j.Name == "" {
+		return j.description()
+	}
+	return fmt.Sprintf("%s: %s", j.Name, j.description())
+}
+
+// spanName returns the name of the span opened for each run.
+func (j *Job) spanName() string {
+	if j.Name == "" {
+		return "job"
+	}
+	return j.Name
+}
+
+// run performs a single iteration of the job with the given parent context.
+// The scheduled time is used to measure how late the iteration started.
+func (j *Job) run(parent context.Context, scheduled time.Time) Status {
+	// Wait for a slot within the scheduler's concurrency limits
+	wait, err := j.scheduler.limiter.acquire(parent, j)
+	if err == errDropped {
+		return j.skip()
+	}
+	if err != nil {
+		// Runs abandoned while waiting are not recorded. A job quit while
+		// waiting stops its iteration loop.
+		if err == ErrJobStopped {
+			j.quitting = true
+		}
+		now := time.Now()
+		return Status{Error: err, Start: now, End: now}
+	}
+
+	hooks, mws := j.lifecycle()
+	for _, h := range hooks {
+		h.beforeRun(j)
+	}
+
+	status := Status{Start: time.Now(), Wait: wait}
+	j.setStarted(status.Start)
+	j.scheduler.metrics.start(j, status.Start.Sub(scheduled))
+
+	// Run the job until it succeeds or its retries are exhausted, and record
+	// the time elapsed
+	exec := chain(j.exec, mws)
+	status.Error = j.attempt(parent, exec, &status, scheduled, 1)
+	for n := 2; status.Error != nil && n <= j.retries+1 && parent.Err() == nil; n += 1 {
+		status.Error = j.attempt(parent, exec, &status, scheduled, n)
+	}
+	status.End = time.Now()
+	j.setStarted(time.Time{})
+	j.scheduler.limiter.release(j)
+	j.scheduler.metrics.finish(j, status)
+
+	// Send the status to the logger
+	j.scheduler.logger.Log(status)
+	j.record(status)
+
+	for _, h := range hooks {
+		h.afterRun(j, status)
+	}
+	return status
+}
+
+// setStarted sets the start time of the run in progress, or zero once it
+// has finished.
+func (j *Job) setStarted(start time.Time) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.started = start
+	if !start.IsZero() {
+		j.lastStart = start
+	}
+}
+
+// attempt performs a single attempt of a run within its own span. Scheduled
+// runs are root spans. The context of the attempt is cancelled after the
+// job's timeout, and carries the status of the run for commands to record
+// their output.
+func (j *Job) attempt(parent context.Context, exec JobFunc, status *Status, scheduled time.Time, n int) error {
+	ctx, span := j.scheduler.tracer.Start(
+		parent,
+		j.spanName(),
+		Attribute{AttrJobName, j.Name},
+		Attribute{AttrJobScheduled, scheduled},
+		Attribute{AttrJobAttempt, n},
+	)
+	defer span.End()
+	ctx = ContextWithSpan(ctx, span)
+	ctx = context.WithValue(ctx, statusKey{}, status)
+
+	if j.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, j.timeout)
+		defer cancel()
+	}
+	err := exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+	}
+	return err
+}
+
+// Run will start the job's iteration loop. The job will run on the next
+// occurrence of its schedule, or the next tick of its time channel. Jobs are
+// repeated until their schedule has no next occurrence, their number of
+// runs is exhausted, or the quit signal is received. During a job's
+// iteration, the job's parent scheduler has its wait group incremented. The
+// wait group is then decremented upon completion of the iteration loop or
+// receiving a quit signal.
 func (j *Job) Run() {
-	// Add another job to this scheduler's wait group
+	j.triggers = make(chan triggerRequest)
+	j.schedules = make(chan scheduleRequest)
+	j.resumed = make(chan struct{}, 1)
+	j.done = make(chan struct{})
+
+	// Add another job to this scheduler's wait group and registry
 	j.scheduler.unfinished.Add(1)
+	j.scheduler.register(j)
+
+	// Set the first tick of a scheduled job
+	if j.immediately {
+		j.reset(j.delayed(time.Now()))
+	} else if j.schedule != nil {
+		j.advance()
+	}
 
 	// Perform all iterations of the job in the same goroutine
 	go func() {
 		// Main iteration loop
 	Loop:
-		for i := 0; i < j.n; i += j.increment {
+		for i := 0; !j.limited || i < j.times; {
+			// Jobs with a schedule stop once there is no next occurrence
+			if j.quitting || j.schedule != nil && j.tick == nil {
+				break Loop
+			}
+
 			select {
 			case <-j.quit:
 				// Quit the iteration loop
 				break Loop
-			case <-j.tick:
-				// Run the job and record the time elapsed
-				status := Status{Start: time.Now()}
-				status.Error = j.exec()
-				status.End = time.Now()
-
-				// Send the status to the logger
-				j.scheduler.logger.Log(status)
-
-				// Reset the tick if a setter is present
-				if j.setter != nil {
-					j.tick = j.setter()
+			case req := <-j.triggers:
+				// Triggered runs only count as iterations if requested
+				req.result <- j.run(req.ctx, time.Now())
+				if j.countsTriggers() {
+					i += 1
+				}
+			case req := <-j.schedules:
+				// The new schedule replaces the tick channel
+				req.result <- j.setSchedule(req.schedule)
+			case <-j.resumed:
+				// Ticks missed while paused may be made up with a single run
+				if !j.misfired() {
+					continue
+				}
+				j.run(context.Background(), time.Now())
+				i += 1
+			case tick := <-j.tick:
+				// Lateness is measured from the scheduled time if known
+				scheduled := j.Next()
+				if scheduled.IsZero() {
+					scheduled = tick
+				}
+
+				// Runs during a blackout may wait for it to end
+				skipped, deferred := j.holdTick()
+				if deferred {
+					continue
+				}
+
+				// Ticks received while paused are not iterations
+				if !skipped && !j.missTick(scheduled) {
+					j.run(context.Background(), scheduled)
+					i += 1
+				}
+
+				// Scheduled jobs wait for the next occurrence
+				if j.schedule != nil {
+					j.advance()
 				}
 			}
 		}
 
+		// The job will not run again
+		j.mu.Lock()
+		j.next = time.Time{}
+		j.mu.Unlock()
+		j.scheduler.metrics.scheduled(j, time.Time{})
+
+		// Remove this job from this scheduler's registry
+		close(j.done)
+		j.scheduler.unregister(j)
+
+		hooks, _ := j.lifecycle()
+		for _, h := range hooks {
+			h.onQuit(j)
+		}
+
 		// Remove this job from this scheduler's wait group
 		j.scheduler.unfinished.Done()
 	}()
 }
 
 // Quit will stop the job. If a job is in progress, then it will be completed
-// before the job quits.
+// before the job quits. Quit returns once the job has stopped. Quitting a job
+// that has already stopped does nothing.
 func (j *Job) Quit() {
-	j.quit <- true
+	select {
+	case j.quit <- true:
+		<-j.done
+	case <-j.done:
+	}
 }
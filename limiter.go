@@ -0,0 +1,302 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// QueuePolicy determines how runs wait for a slot when the concurrency limits
+// of their scheduler or group are reached.
+type QueuePolicy int
+
+const (
+	// QueuePriority starts waiting runs in order of their job's priority,
+	// then in the order they arrived. Since jobs have a priority of zero
+	// unless set, runs of jobs without priorities start in order of arrival.
+	QueuePriority QueuePolicy = iota
+
+	// QueueFIFO starts waiting runs in the order they arrived, ignoring
+	// their priorities.
+	QueueFIFO
+
+	// QueueDrop does not wait. Runs without a free slot are skipped.
+	QueueDrop
+)
+
+// slots counts the running jobs against a concurrency limit. A limit of zero
+// or less is unlimited.
+type slots struct {
+	limit   int
+	running int
+}
+
+func (s *slots) free() bool {
+	return s == nil || s.limit <= 0 || s.running < s.limit
+}
+
+// waiter is a run waiting for a slot.
+type waiter struct {
+	job   *Job
+	seq   int
+	since time.Time
+	ready chan struct{}
+}
+
+// limiter enforces the global and group concurrency limits of a scheduler.
+type limiter struct {
+	mu      sync.Mutex
+	policy  QueuePolicy
+	global  slots
+	groups  map[string]*slots
+	waiting []*waiter
+	seq     int
+	aging   time.Duration
+	now     func() time.Time
+}
+
+// fits returns true if the job can take a slot. The lock must be held.
+func (l *limiter) fits(j *Job) bool {
+	return l.global.free() && l.groups[j.group].free()
+}
+
+// take increments the running counts for the job. The lock must be held.
+func (l *limiter) take(j *Job) {
+	l.global.running += 1
+	if j.group == "" {
+		return
+	}
+	if l.groups == nil {
+		l.groups = make(map[string]*slots)
+	}
+	g := l.groups[j.group]
+	if g == nil {
+		g = &slots{}
+		l.groups[j.group] = g
+	}
+	g.running += 1
+}
+
+// errDropped is returned by acquire when a run is dropped by QueueDrop.
+var errDropped = errors.New("schedule: no free slot")
+
+// acquire blocks until the job can run within the limits and returns the
+// time spent waiting. It returns errDropped if the run was dropped, the
+// context's error if it was done while waiting, or ErrJobStopped if the job
+// was quit while waiting. It must be called from the job's iteration loop.
+func (l *limiter) acquire(ctx context.Context, j *Job) (time.Duration, error) {
+	l.mu.Lock()
+	if l.fits(j) {
+		l.take(j)
+		l.mu.Unlock()
+		return 0, nil
+	}
+	if l.policy == QueueDrop {
+		l.mu.Unlock()
+		return 0, errDropped
+	}
+	l.seq += 1
+	w := &waiter{job: j, seq: l.seq, since: l.now(), ready: make(chan struct{})}
+	l.waiting = append(l.waiting, w)
+	l.mu.Unlock()
+
+	var err error
+	select {
+	case <-w.ready:
+		return l.now().Sub(w.since), nil
+	case <-ctx.Done():
+		err = ctx.Err()
+	case <-j.quit:
+		err = ErrJobStopped
+	}
+
+	// Abandon the wait, giving back the slot if it was taken meanwhile
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	select {
+	case <-w.ready:
+		l.free(j)
+	default:
+		l.remove(w)
+	}
+	return 0, err
+}
+
+// remove removes the waiting run from the queue. The lock must be held.
+func (l *limiter) remove(w *waiter) {
+	for i, other := range l.waiting {
+		if other == w {
+			copy(l.waiting[i:], l.waiting[i+1:])
+			l.waiting[len(l.waiting)-1] = nil
+			l.waiting = l.waiting[:len(l.waiting)-1]
+			return
+		}
+	}
+}
+
+// release frees the job's slot and starts any waiting runs that now fit.
+func (l *limiter) release(j *Job) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.free(j)
+}
+
+// free decrements the running counts for the job and starts any waiting runs
+// that now fit. The lock must be held.
+func (l *limiter) free(j *Job) {
+	l.global.running -= 1
+	if g := l.groups[j.group]; g != nil {
+		g.running -= 1
+	}
+	l.dispatch()
+}
+
+// dispatch starts the waiting runs that fit, in the order of the queue
+// policy. The lock must be held.
+func (l *limiter) dispatch() {
+	l.order()
+	remaining := l.waiting[:0]
+	for _, w := range l.waiting {
+		if l.fits(w.job) {
+			l.take(w.job)
+			close(w.ready)
+		} else {
+			remaining = append(remaining, w)
+		}
+	}
+	// Clear the tail so released waiters can be collected
+	for i := len(remaining); i < len(l.waiting); i += 1 {
+		l.waiting[i] = nil
+	}
+	l.waiting = remaining
+}
+
+// order sorts the waiting runs in the order they will be started. The lock
+// must be held.
+func (l *limiter) order() {
+	if l.policy == QueuePriority {
+		sort.Sort(byPriority{l.waiting, l.now(), l.aging})
+	} else {
+		sort.Sort(bySeq(l.waiting))
+	}
+}
+
+// setGlobal sets the limit of concurrent runs across all groups.
+func (l *limiter) setGlobal(n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.global.limit = n
+	l.dispatch()
+}
+
+// setGroup sets the limit of concurrent runs of the given group.
+func (l *limiter) setGroup(group string, n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.groups == nil {
+		l.groups = make(map[string]*slots)
+	}
+	g := l.groups[group]
+	if g == nil {
+		g = &slots{}
+		l.groups[group] = g
+	}
+	g.limit = n
+	l.dispatch()
+}
+
+// setPolicy sets the queue policy. Runs that are already waiting keep
+// waiting if the policy is changed to QueueDrop.
+func (l *limiter) setPolicy(p QueuePolicy) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.policy = p
+}
+
+// setAging sets the interval of waiting that raises a run's priority by one.
+func (l *limiter) setAging(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.aging = d
+}
+
+// bySeq implements the `sort.Interface` for waiters in order of arrival
+type bySeq []*waiter
+
+func (ws bySeq) Len() int           { return len(ws) }
+func (ws bySeq) Swap(i, j int)      { ws[i], ws[j] = ws[j], ws[i] }
+func (ws bySeq) Less(i, j int) bool { return ws[i].seq < ws[j].seq }
+
+// byPriority implements the `sort.Interface` for waiters in order of their
+// aged priority, highest first, then in order of arrival
+type byPriority struct {
+	waiters []*waiter
+	now     time.Time
+	aging   time.Duration
+}
+
+func (ws byPriority) Len() int      { return len(ws.waiters) }
+func (ws byPriority) Swap(i, j int) { ws.waiters[i], ws.waiters[j] = ws.waiters[j], ws.waiters[i] }
+func (ws byPriority) Less(i, j int) bool {
+	a, b := ws.priority(ws.waiters[i]), ws.priority(ws.waiters[j])
+	if a != b {
+		return a > b
+	}
+	return ws.waiters[i].seq < ws.waiters[j].seq
+}
+
+// priority returns the priority of the job of the waiting run, raised by one
+// for every interval of aging it has waited.
+func (ws byPriority) priority(w *waiter) int {
+	p := w.job.priority
+	if ws.aging > 0 {
+		p += int(ws.now.Sub(w.since) / ws.aging)
+	}
+	return p
+}
+
+// SetMaxConcurrent limits the number of jobs on the Scheduler that run at
+// the same time. Runs beyond the limit wait for a slot according to the
+// Scheduler's QueuePolicy. Zero or less is unlimited, which is the default.
+func (s *Scheduler) SetMaxConcurrent(n int) {
+	s.limiter.setGlobal(n)
+}
+
+// SetGroupLimit limits the number of jobs of the given group that run at the
+// same time. Jobs are added to a group with the Group option.
+func (s *Scheduler) SetGroupLimit(group string, n int) {
+	s.limiter.setGroup(group, n)
+}
+
+// SetQueuePolicy sets how runs wait for a slot when a concurrency limit is
+// reached. The default is QueuePriority.
+func (s *Scheduler) SetQueuePolicy(p QueuePolicy) {
+	s.limiter.setPolicy(p)
+}
+
+// SetPriorityAging raises the priority of a waiting run by one for every
+// interval of the given duration it has waited, so that runs of low priority
+// jobs are not starved by a steady arrival of high priority runs. Zero or
+// less disables aging, which is the default.
+func (s *Scheduler) SetPriorityAging(d time.Duration) {
+	s.limiter.setAging(d)
+}
+
+// Group adds the job to the named group. The concurrency of the group can be
+// limited with the scheduler's SetGroupLimit.
+func Group(name string) Option {
+	return func(j *Job) {
+		j.group = name
+	}
+}
+
+// Priority sets the priority of the job's runs when they wait for a slot.
+// Higher priorities run first. The default is zero. Priorities are ignored
+// under the QueueFIFO policy.
+func Priority(p int) Option {
+	return func(j *Job) {
+		j.priority = p
+	}
+}
@@ -0,0 +1,257 @@
+package schedule
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// newBlockingJob creates a running job on the scheduler that reports the
+// start of every run and blocks until it is released.
+func newBlockingJob(s *Scheduler, name string, opts ...Option) (*Job, chan string, chan bool) {
+	started := make(chan string, 10)
+	release := make(chan bool)
+	j := s.WheneverCtx(niladic(func() error {
+		started <- name
+		<-release
+		return nil
+	}), make(chan time.Time), append([]Option{Named(name)}, opts...)...)
+	return j, started, release
+}
+
+// triggerAsync triggers the job in a new goroutine and returns a channel of
+// its status.
+func triggerAsync(j *Job) chan Status {
+	result := make(chan Status, 1)
+	go func() {
+		status, _ := j.Trigger()
+		result <- status
+	}()
+	return result
+}
+
+// waitForQueue waits until the given number of runs are waiting for a slot.
+func waitForQueue(t *testing.T, l *limiter, n int) {
+	for i := 0; i < 1000; i += 1 {
+		l.mu.Lock()
+		waiting := len(l.waiting)
+		l.mu.Unlock()
+		if waiting == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("Expected %d runs waiting for a slot", n)
+}
+
+func TestScheduler_SetMaxConcurrent(t *testing.T) {
+	s := New()
+	s.SetMaxConcurrent(1)
+	a, startedA, releaseA := newBlockingJob(s, "a")
+	b, startedB, releaseB := newBlockingJob(s, "b")
+
+	resultA := triggerAsync(a)
+	<-startedA
+	resultB := triggerAsync(b)
+	waitForQueue(t, &s.limiter, 1)
+
+	// The second job starts once the first releases its slot
+	releaseA <- true
+	<-resultA
+	<-startedB
+	releaseB <- true
+	if status := <-resultB; status.Wait <= 0 {
+		t.Error("The status should report the time spent waiting for a slot")
+	}
+	a.Quit()
+	b.Quit()
+}
+
+func TestScheduler_SetGroupLimit(t *testing.T) {
+	s := New()
+	s.SetGroupLimit("warehouse", 1)
+	a, startedA, releaseA := newBlockingJob(s, "a", Group("warehouse"))
+	b, startedB, releaseB := newBlockingJob(s, "b", Group("warehouse"))
+	c, startedC, releaseC := newBlockingJob(s, "c")
+
+	resultA := triggerAsync(a)
+	<-startedA
+	resultB := triggerAsync(b)
+	waitForQueue(t, &s.limiter, 1)
+
+	// Jobs outside of the group are not limited
+	resultC := triggerAsync(c)
+	<-startedC
+	releaseC <- true
+	<-resultC
+
+	releaseA <- true
+	<-resultA
+	<-startedB
+	releaseB <- true
+	<-resultB
+	a.Quit()
+	b.Quit()
+	c.Quit()
+}
+
+func TestScheduler_QueueDrop(t *testing.T) {
+	s := New()
+	s.SetMaxConcurrent(1)
+	s.SetQueuePolicy(QueueDrop)
+	a, startedA, releaseA := newBlockingJob(s, "a")
+	b, _, _ := newBlockingJob(s, "b")
+
+	resultA := triggerAsync(a)
+	<-startedA
+	status, err := b.Trigger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Skipped {
+		t.Error("A run without a free slot should be dropped")
+	}
+	expectInt(t, len(b.History()), 1)
+
+	releaseA <- true
+	<-resultA
+	a.Quit()
+	b.Quit()
+}
+
+func TestScheduler_AbandonWait(t *testing.T) {
+	s := New()
+	s.SetMaxConcurrent(1)
+	a, startedA, releaseA := newBlockingJob(s, "a")
+	b, _, _ := newBlockingJob(s, "b")
+	c, startedC, releaseC := newBlockingJob(s, "c")
+
+	resultA := triggerAsync(a)
+	<-startedA
+
+	// A run whose context is done stops waiting and leaves the queue
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan Status, 1)
+	go func() {
+		status, _ := b.TriggerCtx(ctx)
+		result <- status
+	}()
+	waitForQueue(t, &s.limiter, 1)
+	cancel()
+	waitForQueue(t, &s.limiter, 0)
+	<-result
+
+	// A job quit while waiting stops
+	resultC := triggerAsync(c)
+	waitForQueue(t, &s.limiter, 1)
+	quit := make(chan bool)
+	go func() {
+		c.Quit()
+		quit <- true
+	}()
+	select {
+	case <-quit:
+	case <-time.After(time.Second):
+		t.Fatal("A job waiting for a slot should quit")
+	}
+	waitForQueue(t, &s.limiter, 0)
+	if c.Running() {
+		t.Error("The quit job should not be running")
+	}
+	expectInt(t, len(c.History()), 0)
+
+	releaseA <- true
+	<-resultA
+	select {
+	case <-startedC:
+		releaseC <- true
+		t.Error("The quit job should not have started")
+	default:
+	}
+	<-resultC
+	a.Quit()
+	b.Quit()
+
+	// Abandoned runs are not recorded as skipped
+	expectInt(t, len(b.History()), 0)
+}
+
+func TestScheduler_Priority(t *testing.T) {
+	s := New()
+	s.SetMaxConcurrent(1)
+
+	started := make(chan string, 10)
+	release := make(chan bool)
+	newJob := func(name string, priority int) *Job {
+		return s.WheneverCtx(niladic(func() error {
+			started <- name
+			<-release
+			return nil
+		}), make(chan time.Time), Named(name), Priority(priority))
+	}
+	holder := newJob("holder", 0)
+	low := newJob("low", 1)
+	high := newJob("high", 10)
+
+	results := []chan Status{triggerAsync(holder)}
+	expectString(t, <-started, "holder")
+	results = append(results, triggerAsync(low))
+	waitForQueue(t, &s.limiter, 1)
+	results = append(results, triggerAsync(high))
+	waitForQueue(t, &s.limiter, 2)
+
+	// The high priority run starts first although it arrived last
+	release <- true
+	expectString(t, <-started, "high")
+	release <- true
+	expectString(t, <-started, "low")
+	release <- true
+	for _, result := range results {
+		<-result
+	}
+	holder.Quit()
+	low.Quit()
+	high.Quit()
+}
+
+func TestScheduler_QueueFIFO(t *testing.T) {
+	l := &limiter{policy: QueueFIFO, now: defaultNow}
+	high := &waiter{job: &Job{Name: "high", priority: 10}, seq: 2}
+	low := &waiter{job: &Job{Name: "low"}, seq: 1}
+	l.waiting = []*waiter{high, low}
+
+	// Priorities are ignored
+	l.order()
+	expectString(t, l.waiting[0].job.Name, "low")
+}
+
+func TestScheduler_SetPriorityAging(t *testing.T) {
+	start := time.Date(2014, time.Month(2), 14, 0, 0, 0, 0, time.UTC)
+	now := start
+	s := New()
+	s.limiter.now = func() time.Time { return now }
+	s.SetPriorityAging(time.Minute)
+
+	// A low priority run has waited since the start, and a high priority
+	// run arrives now
+	low := &waiter{job: &Job{Name: "low", priority: 1}, seq: 1, since: start}
+	first := func() string {
+		high := &waiter{job: &Job{Name: "high", priority: 5}, seq: 2, since: now}
+		s.limiter.waiting = []*waiter{high, low}
+		s.limiter.order()
+		return s.limiter.waiting[0].job.Name
+	}
+
+	now = start.Add(2 * time.Minute)
+	expectString(t, first(), "high") // 1 + 2 < 5
+
+	now = start.Add(4 * time.Minute)
+	expectString(t, first(), "low") // 1 + 4 == 5, then in order of arrival
+
+	now = start.Add(10 * time.Minute)
+	expectString(t, first(), "low") // 1 + 10 > 5
+
+	// Without aging, the low priority run would wait forever
+	s.SetPriorityAging(0)
+	expectString(t, first(), "high")
+}
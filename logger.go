@@ -17,3 +17,20 @@ type DefaultLogger struct{}
 func (l *DefaultLogger) Log(s Status) {
 	log.Println(s)
 }
+
+// LogWorkflow prints the status of a workflow run and its steps to the `log`
+// package logger.
+func (l *DefaultLogger) LogWorkflow(ws WorkflowStatus) {
+	log.Println(ws)
+}
+
+// LogReload prints the summary of a configuration reload to the `log`
+// package logger.
+func (l *DefaultLogger) LogReload(rs ReloadSummary) {
+	log.Println(rs)
+}
+
+// LogAlert prints an alert raised by a Watchdog to the `log` package logger.
+func (l *DefaultLogger) LogAlert(a Alert) {
+	log.Println("ALERT:", a)
+}
@@ -0,0 +1,248 @@
+package schedule
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBuckets are the upper bounds, in seconds, of the histogram buckets
+// used for run durations and start lag.
+var DefaultBuckets = []float64{
+	0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300,
+}
+
+// OpenMetricsContentType is the content type of the Metrics exposition.
+const OpenMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// histogram counts observations in cumulative buckets.
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+func (h *histogram) observe(buckets []float64, v float64) {
+	if h.counts == nil {
+		h.counts = make([]uint64, len(buckets))
+	}
+	for i, bound := range buckets {
+		if v <= bound {
+			h.counts[i] += 1
+		}
+	}
+	h.count += 1
+	h.sum += v
+}
+
+// jobMetrics are the metrics recorded for a single job name.
+type jobMetrics struct {
+	success     uint64
+	failure     uint64
+	inFlight    int
+	lastSuccess time.Time
+	next        map[*Job]time.Time // Next run of each job with the name
+	duration    histogram
+	lag         histogram
+}
+
+// nextRun returns the earliest next run of the jobs with the name, or a zero
+// time if none have a next run.
+func (jm *jobMetrics) nextRun() time.Time {
+	var next time.Time
+	for _, t := range jm.next {
+		if next.IsZero() || t.Before(next) {
+			next = t
+		}
+	}
+	return next
+}
+
+// Metrics records counters, gauges, and histograms for job runs and exposes
+// them in the OpenMetrics text format. Jobs are identified by their Name, or
+// by "job <id>" if they have none. The metrics of jobs that share a name are
+// combined, and their next run is the earliest of their next runs. Metrics
+// are fed by the jobs of a Scheduler once set with SetMetrics. All methods
+// are safe to call on a nil Metrics.
+type Metrics struct {
+	mu      sync.Mutex
+	buckets []float64
+	jobs    map[string]*jobMetrics
+}
+
+func (m *Metrics) job(j *Job) *jobMetrics {
+	name := j.Name
+	if name == "" {
+		name = fmt.Sprintf("job %d", j.ID())
+	}
+	jm, ok := m.jobs[name]
+	if !ok {
+		jm = &jobMetrics{next: make(map[*Job]time.Time)}
+		m.jobs[name] = jm
+	}
+	return jm
+}
+
+// start records that a run has started the given duration after it was
+// scheduled.
+func (m *Metrics) start(j *Job, lag time.Duration) {
+	if m == nil {
+		return
+	}
+	if lag < 0 {
+		lag = 0
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	jm := m.job(j)
+	jm.inFlight += 1
+	jm.lag.observe(m.buckets, lag.Seconds())
+}
+
+// finish records the outcome and duration of a completed run.
+func (m *Metrics) finish(j *Job, s Status) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	jm := m.job(j)
+	jm.inFlight -= 1
+	jm.duration.observe(m.buckets, s.End.Sub(s.Start).Seconds())
+	if s.Error == nil {
+		jm.success += 1
+		jm.lastSuccess = s.End
+	} else {
+		jm.failure += 1
+	}
+}
+
+// scheduled records the next run time of a job. A zero time indicates that
+// the job has no next run.
+func (m *Metrics) scheduled(j *Job, next time.Time) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	jm := m.job(j)
+	if next.IsZero() {
+		delete(jm.next, j)
+	} else {
+		jm.next[j] = next
+	}
+}
+
+// escapeLabel escapes a label value for the exposition format.
+var escapeLabel = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func formatTimestamp(t time.Time) string {
+	return formatFloat(float64(t.UnixNano()) / 1e9)
+}
+
+// WriteTo writes all metrics to the given writer in the OpenMetrics text
+// format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	if m != nil {
+		m.mu.Lock()
+		m.write(&buf)
+		m.mu.Unlock()
+	}
+	buf.WriteString("# EOF\n")
+	return buf.WriteTo(w)
+}
+
+func (m *Metrics) write(buf *bytes.Buffer) {
+	// Output jobs in a stable order
+	names := make([]string, 0, len(m.jobs))
+	for name := range m.jobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	family := func(name, kind, help string) {
+		fmt.Fprintf(buf, "# TYPE %s %s\n# HELP %s %s\n", name, kind, name, help)
+	}
+	writeHistogram := func(name string, h histogram, job string) {
+		for i, bound := range m.buckets {
+			var count uint64
+			if h.counts != nil {
+				count = h.counts[i]
+			}
+			fmt.Fprintf(buf, "%s_bucket{job=\"%s\",le=\"%s\"} %d\n", name, job, formatFloat(bound), count)
+		}
+		fmt.Fprintf(buf, "%s_bucket{job=\"%s\",le=\"+Inf\"} %d\n", name, job, h.count)
+		fmt.Fprintf(buf, "%s_sum{job=\"%s\"} %s\n", name, job, formatFloat(h.sum))
+		fmt.Fprintf(buf, "%s_count{job=\"%s\"} %d\n", name, job, h.count)
+	}
+
+	family("schedule_job_runs", "counter", "Completed job runs by outcome.")
+	for _, name := range names {
+		jm := m.jobs[name]
+		fmt.Fprintf(buf, "schedule_job_runs_total{job=\"%s\",outcome=\"success\"} %d\n", escapeLabel(name), jm.success)
+		fmt.Fprintf(buf, "schedule_job_runs_total{job=\"%s\",outcome=\"error\"} %d\n", escapeLabel(name), jm.failure)
+	}
+
+	family("schedule_job_duration_seconds", "histogram", "Duration of job runs.")
+	for _, name := range names {
+		writeHistogram("schedule_job_duration_seconds", m.jobs[name].duration, escapeLabel(name))
+	}
+
+	family("schedule_job_start_lag_seconds", "histogram", "Delay between the scheduled and actual start of job runs.")
+	for _, name := range names {
+		writeHistogram("schedule_job_start_lag_seconds", m.jobs[name].lag, escapeLabel(name))
+	}
+
+	family("schedule_job_in_flight", "gauge", "Job runs currently in progress.")
+	for _, name := range names {
+		fmt.Fprintf(buf, "schedule_job_in_flight{job=\"%s\"} %d\n", escapeLabel(name), m.jobs[name].inFlight)
+	}
+
+	family("schedule_job_last_success_timestamp_seconds", "gauge", "Time of the last successful job run.")
+	for _, name := range names {
+		if t := m.jobs[name].lastSuccess; !t.IsZero() {
+			fmt.Fprintf(buf, "schedule_job_last_success_timestamp_seconds{job=\"%s\"} %s\n", escapeLabel(name), formatTimestamp(t))
+		}
+	}
+
+	family("schedule_job_next_run_timestamp_seconds", "gauge", "Time of the next scheduled job run.")
+	for _, name := range names {
+		if t := m.jobs[name].nextRun(); !t.IsZero() {
+			fmt.Fprintf(buf, "schedule_job_next_run_timestamp_seconds{job=\"%s\"} %s\n", escapeLabel(name), formatTimestamp(t))
+		}
+	}
+}
+
+// ServeHTTP writes all metrics in the OpenMetrics text format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", OpenMetricsContentType)
+	m.WriteTo(w)
+}
+
+// NewMetrics creates an empty Metrics using the DefaultBuckets.
+func NewMetrics() *Metrics {
+	return NewMetricsWithBuckets(DefaultBuckets)
+}
+
+// NewMetricsWithBuckets creates an empty Metrics using the given histogram
+// bucket upper bounds in seconds. The bounds will be sorted.
+func NewMetricsWithBuckets(buckets []float64) *Metrics {
+	sorted := make([]float64, len(buckets))
+	copy(sorted, buckets)
+	sort.Float64s(sorted)
+	return &Metrics{
+		buckets: sorted,
+		jobs:    make(map[string]*jobMetrics),
+	}
+}
@@ -0,0 +1,84 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func expectContains(t *testing.T, body, substr string) {
+	if !strings.Contains(body, substr) {
+		t.Errorf("Expected output to contain %q:\n%s", substr, body)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	s := New()
+	metrics := NewMetricsWithBuckets([]float64{1, 0.5})
+	s.SetMetrics(metrics)
+
+	var count int
+	j := s.RepeatN(func() error {
+		count += 1
+		if count == 2 {
+			return errors.New("failed")
+		}
+		return nil
+	}, time.Millisecond, 3)
+	s.WaitForJobsToFinish()
+
+	w := httptest.NewRecorder()
+	metrics.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	expectString(t, w.Header().Get("Content-Type"), OpenMetricsContentType)
+
+	// Unnamed jobs are identified by their ID
+	body, name := w.Body.String(), fmt.Sprintf("job %d", j.ID())
+	expectContains(t, body, "# TYPE schedule_job_runs counter\n")
+	expectContains(t, body, `schedule_job_runs_total{job="`+name+`",outcome="success"} 2`)
+	expectContains(t, body, `schedule_job_runs_total{job="`+name+`",outcome="error"} 1`)
+	expectContains(t, body, `schedule_job_duration_seconds_bucket{job="`+name+`",le="0.5"} 3`)
+	expectContains(t, body, `schedule_job_duration_seconds_bucket{job="`+name+`",le="+Inf"} 3`)
+	expectContains(t, body, `schedule_job_duration_seconds_count{job="`+name+`"} 3`)
+	expectContains(t, body, `schedule_job_start_lag_seconds_count{job="`+name+`"} 3`)
+	expectContains(t, body, `schedule_job_in_flight{job="`+name+`"} 0`)
+	expectContains(t, body, `schedule_job_last_success_timestamp_seconds{job="`+name+`"} `)
+	if strings.Contains(body, "schedule_job_next_run_timestamp_seconds{") {
+		t.Error("A finished job should not report a next run")
+	}
+	if !strings.HasSuffix(body, "# EOF\n") {
+		t.Error("The exposition should end with # EOF")
+	}
+}
+
+func TestMetrics_SharedName(t *testing.T) {
+	s := New()
+	metrics := NewMetrics()
+	s.SetMetrics(metrics)
+	a := s.Every(func() error { return nil }, time.Hour, Named("sync"))
+	b := s.Every(func() error { return nil }, 2*time.Hour, Named("sync"))
+	defer b.Quit()
+
+	// Jobs that share a name report the earliest of their next runs
+	next := func() string {
+		var buf strings.Builder
+		metrics.WriteTo(&buf)
+		return buf.String()
+	}
+	expectContains(t, next(), "schedule_job_next_run_timestamp_seconds{job=\"sync\"} "+formatTimestamp(a.Next())+"\n")
+	a.Quit()
+	expectContains(t, next(), "schedule_job_next_run_timestamp_seconds{job=\"sync\"} "+formatTimestamp(b.Next())+"\n")
+}
+
+func TestMetrics_Nil(t *testing.T) {
+	var metrics *Metrics
+	w := httptest.NewRecorder()
+	metrics.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	expectString(t, w.Body.String(), "# EOF\n")
+}
+
+func TestEscapeLabel(t *testing.T) {
+	expectString(t, escapeLabel("a\"b\\c\nd"), `a\"b\\c\nd`)
+}
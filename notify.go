@@ -0,0 +1,122 @@
+package schedule
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryNotifier keeps every notification in memory. It is useful for tests
+// and for showing recent notifications on a dashboard.
+type MemoryNotifier struct {
+	mu            sync.Mutex
+	notifications []Notification
+}
+
+// Notify keeps the notification.
+func (m *MemoryNotifier) Notify(n Notification) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.notifications = append(m.notifications, n)
+	return nil
+}
+
+// Notifications returns the notifications received, oldest first.
+func (m *MemoryNotifier) Notifications() []Notification {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Notification(nil), m.notifications...)
+}
+
+// notificationJSON is the JSON representation of a Notification.
+type notificationJSON struct {
+	Job        string     `json:"job"`
+	Kind       string     `json:"kind"`
+	Reason     string     `json:"reason,omitempty"`
+	Message    string     `json:"message"`
+	Time       time.Time  `json:"time"`
+	Suppressed int        `json:"suppressed,omitempty"`
+	Status     statusJSON `json:"status"`
+}
+
+// webhookTimeout is the timeout of a WebhookNotifier without its own client.
+const webhookTimeout = 10 * time.Second
+
+// WebhookNotifier posts every notification as JSON to a URL. Since
+// notifications block the job that caused them, the client should have a
+// timeout.
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client // A client with a 10 second timeout if nil
+}
+
+// NewWebhookNotifier creates a WebhookNotifier that posts to the URL.
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{URL: url}
+}
+
+// Notify posts the notification. Responses other than 2xx are errors.
+func (w *WebhookNotifier) Notify(n Notification) error {
+	body, err := json.Marshal(notificationJSON{
+		Job:        n.Job,
+		Kind:       n.Kind.String(),
+		Reason:     n.Reason,
+		Message:    n.String(),
+		Time:       n.Time,
+		Suppressed: n.Suppressed,
+		Status:     toStatusJSON(n.Status),
+	})
+	if err != nil {
+		return err
+	}
+	client := w.Client
+	if client == nil {
+		client = &http.Client{Timeout: webhookTimeout}
+	}
+	resp, err := client.Post(w.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("schedule: webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// SMTPNotifier emails every notification. Addr is the host and port of the
+// mail server. Auth may be nil if the server does not require it.
+type SMTPNotifier struct {
+	Addr string
+	Auth smtp.Auth
+	From string
+	To   []string
+}
+
+// message builds the email of the notification.
+func (s *SMTPNotifier) message(n Notification) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", s.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(s.To, ", "))
+	fmt.Fprintf(&b, "Subject: [schedule] %s %s\r\n", n.Job, n.Kind)
+	fmt.Fprintf(&b, "Date: %s\r\n", n.Time.Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&b, "%s\r\n", n)
+	if n.Suppressed > 0 {
+		fmt.Fprintf(&b, "%d notifications were suppressed.\r\n", n.Suppressed)
+	}
+	if n.Status.Stderr != "" {
+		fmt.Fprintf(&b, "\r\n%s\r\n", n.Status.Stderr)
+	}
+	return b.Bytes()
+}
+
+// Notify sends the notification by email.
+func (s *SMTPNotifier) Notify(n Notification) error {
+	return smtp.SendMail(s.Addr, s.Auth, s.From, s.To, s.message(n))
+}
@@ -0,0 +1,136 @@
+package schedule
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func failingNotification() Notification {
+	return Notification{
+		Job:    "backup",
+		Kind:   NotifyFailing,
+		Reason: "3 consecutive failures",
+		Status: runAt(0, errors.New("exit status 1")),
+		Time:   runAt(0, nil).End,
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var received notificationJSON
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	if err := NewWebhookNotifier(server.URL).Notify(failingNotification()); err != nil {
+		t.Fatal(err)
+	}
+	expectString(t, received.Job, "backup")
+	expectString(t, received.Kind, "failing")
+	expectString(t, received.Message, "backup is failing: 3 consecutive failures (last error: exit status 1)")
+	expectString(t, received.Status.Error, "exit status 1")
+
+	// Responses other than 2xx are errors
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	if err := NewWebhookNotifier(failing.URL).Notify(failingNotification()); err == nil {
+		t.Error("An internal server error should be an error")
+	}
+}
+
+// smtpStub is a mail server that accepts a single message.
+type smtpStub struct {
+	listener net.Listener
+	commands []string
+	data     string
+	done     chan struct{}
+}
+
+func newSMTPStub(t *testing.T) *smtpStub {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip(err)
+	}
+	stub := &smtpStub{listener: l, done: make(chan struct{})}
+	go stub.serve()
+	return stub
+}
+
+func (s *smtpStub) serve() {
+	defer close(s.done)
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 localhost stub")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.TrimSpace(line)
+		s.commands = append(s.commands, command)
+		switch verb := strings.ToUpper(strings.SplitN(command, " ", 2)[0]); verb {
+		case "EHLO", "HELO":
+			reply("250 localhost")
+		case "DATA":
+			reply("354 end with .")
+			var data []string
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil || line == ".\r\n" {
+					break
+				}
+				data = append(data, line)
+			}
+			s.data = strings.Join(data, "")
+			reply("250 accepted")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func TestSMTPNotifier(t *testing.T) {
+	stub := newSMTPStub(t)
+	defer stub.listener.Close()
+
+	notifier := &SMTPNotifier{
+		Addr: stub.listener.Addr().String(),
+		From: "schedule@example.com",
+		To:   []string{"ops@example.com", "dev@example.com"},
+	}
+	n := failingNotification()
+	n.Suppressed = 2
+	if err := notifier.Notify(n); err != nil {
+		t.Fatal(err)
+	}
+	<-stub.done
+
+	expectContains(t, strings.Join(stub.commands, "\n"), "RCPT TO:<dev@example.com>")
+	expectContains(t, stub.data, "Subject: [schedule] backup failing\r\n")
+	expectContains(t, stub.data, "To: ops@example.com, dev@example.com\r\n")
+	expectContains(t, stub.data, "backup is failing: 3 consecutive failures (last error: exit status 1)\r\n")
+	expectContains(t, stub.data, "2 notifications were suppressed.")
+}
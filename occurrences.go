@@ -0,0 +1,39 @@
+package schedule
+
+import (
+	"time"
+)
+
+// NextN returns up to n successive occurrences of the schedule strictly after
+// the given time. Fewer are returned if the schedule ends. No jobs or timers
+// are involved, so it is safe to preview any schedule.
+func NextN(s Schedule, from time.Time, n int) []time.Time {
+	var times []time.Time
+	for t := from; len(times) < n; {
+		next := s.Next(t)
+		// Stop at the end of the schedule, or if it fails to advance
+		if next.IsZero() || !next.After(t) {
+			break
+		}
+		times = append(times, next)
+		t = next
+	}
+	return times
+}
+
+// Occurrences returns every occurrence of the schedule strictly after from
+// and at or before to, which are the same occurrences NextN would return.
+// Schedules without an end, such as an Interval, can have many occurrences
+// in a long range.
+func Occurrences(s Schedule, from, to time.Time) []time.Time {
+	var times []time.Time
+	for t := from; ; {
+		next := s.Next(t)
+		if next.IsZero() || !next.After(t) || next.After(to) {
+			break
+		}
+		times = append(times, next)
+		t = next
+	}
+	return times
+}
@@ -0,0 +1,95 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+// expectTimes checks a list of times against the expected times.
+func expectTimes(t *testing.T, times []time.Time, expected ...time.Time) {
+	if len(times) != len(expected) {
+		t.Fatalf("Unexpected number of times: %d != %d", len(times), len(expected))
+	}
+	for i, e := range expected {
+		if !times[i].Equal(e) {
+			t.Errorf("Unexpected time %d: %s != %s", i, times[i], e)
+		}
+	}
+}
+
+// stuck is a custom Schedule that fails to advance.
+type stuck time.Time
+
+func (s stuck) Next(after time.Time) time.Time {
+	return time.Time(s)
+}
+
+func TestNextN(t *testing.T) {
+	denver := loadDenver(t)
+	at := func(month time.Month, day, hour, min int) time.Time {
+		return time.Date(2014, month, day, hour, min, 0, 0, denver)
+	}
+
+	// Clocks spring forward at 2:00 on 2014-03-09
+	daily := MustParseClockIn("9:00:00", denver).Schedule()
+	expectTimes(t, NextN(daily, at(3, 8, 9, 0), 3),
+		at(3, 9, 9, 0),
+		at(3, 10, 9, 0),
+		at(3, 11, 9, 0),
+	)
+
+	// 2014-02-14 was a Friday
+	monday := FromDayAndClock(time.Monday, MustParseClockIn("9:00:00", denver))
+	expectTimes(t, NextN(monday.Schedule(), at(2, 14, 12, 0), 2),
+		at(2, 17, 9, 0),
+		at(2, 24, 9, 0),
+	)
+
+	ticker := DaysAndClocksTicker(
+		[]time.Weekday{time.Friday, time.Monday},
+		[]Clock{MustParseClockIn("17:00:00", denver), MustParseClockIn("9:00:00", denver)},
+	)
+	expectTimes(t, NextN(ticker.Schedule(), at(2, 14, 12, 0), 3),
+		at(2, 14, 17, 0),
+		at(2, 17, 9, 0),
+		at(2, 17, 17, 0),
+	)
+
+	// Schedules that end return fewer occurrences
+	once := At(at(2, 14, 12, 0))
+	expectTimes(t, NextN(once, at(2, 14, 0, 0), 5), at(2, 14, 12, 0))
+	expectTimes(t, NextN(Never, at(2, 14, 0, 0), 5))
+	expectTimes(t, NextN(stuck(at(2, 14, 12, 0)), at(2, 14, 0, 0), 5), at(2, 14, 12, 0))
+}
+
+func TestOccurrences(t *testing.T) {
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2014, time.Month(2), day, hour, min, 0, 0, time.UTC)
+	}
+
+	// The start is excluded and the end is included
+	every := Interval(30 * time.Minute)
+	expectTimes(t, Occurrences(every, at(14, 9, 0), at(14, 10, 15)),
+		at(14, 9, 30),
+		at(14, 10, 0),
+	)
+
+	clocks := Clocks{MustParseClockUTC("3:00:00"), MustParseClockUTC("15:00:00")}
+	expectTimes(t, Occurrences(clocks, at(14, 3, 0), at(15, 15, 0)),
+		at(14, 15, 0),
+		at(15, 3, 0),
+		at(15, 15, 0),
+	)
+
+	cron, err := ParseCronIn("0 9 * * mon-fri", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectTimes(t, Occurrences(cron, at(14, 12, 0), at(19, 0, 0)),
+		at(17, 9, 0),
+		at(18, 9, 0),
+	)
+
+	expectTimes(t, Occurrences(clocks, at(15, 0, 0), at(14, 0, 0)))
+	expectTimes(t, Occurrences(stuck(at(14, 12, 0)), at(14, 0, 0), at(15, 0, 0)), at(14, 12, 0))
+}
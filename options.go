@@ -0,0 +1,49 @@
+package schedule
+
+import (
+	"time"
+)
+
+// Option configures a job before it starts running.
+type Option func(*Job)
+
+// Named sets the name of the job.
+func Named(name string) Option {
+	return func(j *Job) {
+		j.Name = name
+	}
+}
+
+// Immediately runs the job once as soon as it starts, before the first
+// occurrence of its schedule.
+func Immediately() Option {
+	return func(j *Job) {
+		j.immediately = true
+	}
+}
+
+// Times limits the job to the given number of runs. Triggered runs are only
+// counted if SetCountTriggers is enabled.
+func Times(n int) Option {
+	return func(j *Job) {
+		j.times = n
+		j.limited = true
+	}
+}
+
+// Timeout cancels the context of every run of the job after the given
+// duration. The job's function must respect its context to be stopped.
+func Timeout(d time.Duration) Option {
+	return func(j *Job) {
+		j.timeout = d
+	}
+}
+
+// Retries runs the job again immediately, up to the given number of times,
+// if it returns an error. Each attempt has its own span and timeout. Only the
+// status of the last attempt is recorded.
+func Retries(n int) Option {
+	return func(j *Job) {
+		j.retries = n
+	}
+}
@@ -0,0 +1,64 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimeout(t *testing.T) {
+	s := New()
+	j := s.ScheduleCtx(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, Never, Immediately(), Timeout(10*time.Millisecond))
+	s.WaitForJobsToFinish()
+
+	history := j.History()
+	expectInt(t, len(history), 1)
+	if history[0].Error != context.DeadlineExceeded {
+		t.Errorf("The run should time out, got %v", history[0].Error)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	s := New()
+	tracer := &RecordingTracer{}
+	s.SetTracer(tracer)
+
+	var attempts int
+	j := s.Now(func() error {
+		attempts += 1
+		if attempts < 3 {
+			return errors.New("flaky")
+		}
+		return nil
+	}, Retries(5))
+	s.WaitForJobsToFinish()
+
+	// The job stops retrying once it succeeds
+	expectInt(t, attempts, 3)
+	history := j.History()
+	expectInt(t, len(history), 1)
+	if history[0].Error != nil {
+		t.Errorf("The last attempt should succeed, got %s", history[0].Error)
+	}
+
+	// Every attempt has its own span
+	spans := tracer.Spans()
+	expectInt(t, len(spans), 3)
+	expectInt(t, spans[2].Attribute(AttrJobAttempt).(int), 3)
+
+	// Retries are exhausted
+	attempts = -10
+	j = s.Now(func() error {
+		attempts += 1
+		return errors.New("broken")
+	}, Retries(2))
+	s.WaitForJobsToFinish()
+	expectInt(t, attempts, -7)
+	if j.History()[0].Error == nil {
+		t.Error("The run should fail once its retries are exhausted")
+	}
+}
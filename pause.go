@@ -0,0 +1,117 @@
+package schedule
+
+import (
+	"time"
+)
+
+// PausePolicy determines what happens to the ticks a job receives while it
+// is paused.
+type PausePolicy int
+
+const (
+	// DropPaused silently drops ticks received while paused.
+	DropPaused PausePolicy = iota
+
+	// SkipPaused logs and records a skipped Status for every tick received
+	// while paused.
+	SkipPaused
+)
+
+// MisfirePolicy determines what happens when a job is resumed after it
+// missed one or more ticks while paused.
+type MisfirePolicy int
+
+const (
+	// MisfireSkip waits for the job's next tick.
+	MisfireSkip MisfirePolicy = iota
+
+	// MisfireRunOnce runs the job once immediately, regardless of how many
+	// ticks were missed.
+	MisfireRunOnce
+)
+
+// Pause stops the job from running on its ticks until it is resumed. The
+// job keeps its schedule. Pausing does not interrupt a run in progress or
+// prevent the job from being triggered.
+func (j *Job) Pause() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.paused = true
+}
+
+// Resume allows a paused job to run on its ticks again. If ticks were
+// missed while paused, the job's MisfirePolicy is applied.
+func (j *Job) Resume() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if !j.paused {
+		return
+	}
+	j.paused = false
+
+	// Wake the iteration loop without blocking if it is already awake
+	select {
+	case j.resumed <- struct{}{}:
+	default:
+	}
+}
+
+// Paused returns true if the job is paused.
+func (j *Job) Paused() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.paused
+}
+
+// SetPausePolicy sets what happens to ticks received while the job is
+// paused. The default is DropPaused.
+func (j *Job) SetPausePolicy(p PausePolicy) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.pausePolicy = p
+}
+
+// SetMisfirePolicy sets what happens when the job is resumed after missing
+// ticks. The default is MisfireSkip.
+func (j *Job) SetMisfirePolicy(p MisfirePolicy) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.misfirePolicy = p
+}
+
+// missTick handles a tick if the job is paused. It returns false if the job
+// is not paused and should run.
+func (j *Job) missTick(scheduled time.Time) bool {
+	j.mu.Lock()
+	if !j.paused {
+		j.mu.Unlock()
+		return false
+	}
+	j.missed += 1
+	policy := j.pausePolicy
+	j.mu.Unlock()
+
+	if policy == SkipPaused {
+		j.skip()
+	}
+	return true
+}
+
+// skip logs, records, and returns a skipped Status.
+func (j *Job) skip() Status {
+	now := time.Now()
+	status := Status{Start: now, End: now, Skipped: true}
+	j.scheduler.logger.Log(status)
+	j.record(status)
+	return status
+}
+
+// misfired returns true if the job missed ticks while paused and should
+// run once now that it has resumed. The count of missed ticks is reset.
+func (j *Job) misfired() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	missed := j.missed
+	j.missed = 0
+	return !j.paused && missed > 0 && j.misfirePolicy == MisfireRunOnce
+}
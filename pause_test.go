@@ -0,0 +1,85 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+// newPauseJob creates a job on a manual tick channel that reports every run.
+func newPauseJob() (*Job, chan time.Time, chan bool) {
+	tick := make(chan time.Time)
+	runs := make(chan bool, 10)
+	j := New().whenever(niladic(func() error {
+		runs <- true
+		return nil
+	}), tick)
+	return j, tick, runs
+}
+
+func TestJob_Pause(t *testing.T) {
+	j, tick, runs := newPauseJob()
+	j.Run()
+
+	j.Pause()
+	if !j.Paused() {
+		t.Fatal("The job should be paused")
+	}
+	tick <- time.Now()
+	tick <- time.Now()
+
+	// Triggering the job guarantees the ticks have been handled
+	j.Trigger()
+
+	// The default misfire policy waits for the next tick
+	j.Resume()
+	if j.Paused() {
+		t.Fatal("The job should not be paused")
+	}
+	tick <- time.Now()
+	j.Quit()
+
+	expectInt(t, len(runs), 2)
+	expectInt(t, len(j.History()), 2)
+}
+
+func TestJob_PausePolicy(t *testing.T) {
+	j, tick, runs := newPauseJob()
+	j.SetPausePolicy(SkipPaused)
+	j.Run()
+
+	j.Pause()
+	tick <- time.Now()
+	j.Quit()
+
+	expectInt(t, len(runs), 0)
+	history := j.History()
+	if len(history) != 1 || !history[0].Skipped {
+		t.Fatalf("Expected a single skipped status: %v", history)
+	}
+	expectString(t, history[0].String(), "SKIPPED")
+}
+
+func TestJob_MisfirePolicy(t *testing.T) {
+	j, tick, runs := newPauseJob()
+	j.SetMisfirePolicy(MisfireRunOnce)
+	j.Run()
+
+	// Resuming without missed ticks should not run the job
+	j.Pause()
+	j.Resume()
+
+	// Two missed ticks should be made up with a single run
+	j.Pause()
+	tick <- time.Now()
+	tick <- time.Now()
+	j.Trigger()
+	<-runs
+	j.Resume()
+	select {
+	case <-runs:
+	case <-time.After(time.Second):
+		t.Fatal("The job should have run once it was resumed")
+	}
+	j.Quit()
+	expectInt(t, len(runs), 0)
+}
@@ -0,0 +1,295 @@
+package schedule
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ReloadSummary describes the changes made to the running jobs by a reload
+// of a configuration file. Jobs are identified by name. Error is set if the
+// configuration could not be loaded, in which case no jobs were changed.
+type ReloadSummary struct {
+	Path        string
+	Time        time.Time
+	Added       []string
+	Removed     []string
+	Rescheduled []string
+	Replaced    []string
+	Unchanged   int
+	Error       error
+}
+
+// Changed returns true if any job was added, removed, rescheduled, or
+// replaced.
+func (rs ReloadSummary) Changed() bool {
+	return len(rs.Added)+len(rs.Removed)+len(rs.Rescheduled)+len(rs.Replaced) > 0
+}
+
+// String returns a description such as "reloaded jobs.conf: added backup;
+// rescheduled sync; 2 unchanged".
+func (rs ReloadSummary) String() string {
+	if rs.Error != nil {
+		return fmt.Sprintf("reload of %s failed: %s", rs.Path, rs.Error)
+	}
+	var changes []string
+	for _, change := range []struct {
+		verb  string
+		names []string
+	}{
+		{"added", rs.Added},
+		{"removed", rs.Removed},
+		{"rescheduled", rs.Rescheduled},
+		{"replaced", rs.Replaced},
+	} {
+		if len(change.names) > 0 {
+			changes = append(changes, change.verb+" "+strings.Join(change.names, ", "))
+		}
+	}
+	changes = append(changes, fmt.Sprintf("%d unchanged", rs.Unchanged))
+	return fmt.Sprintf("reloaded %s: %s", rs.Path, strings.Join(changes, "; "))
+}
+
+// ReloadLogger is a Logger that can also log the summaries of configuration
+// reloads. Schedulers with a Logger that does not implement ReloadLogger log
+// a Status with the error of every failed reload instead.
+type ReloadLogger interface {
+	Logger
+	LogReload(ReloadSummary)
+}
+
+// logReload sends the summary of a reload to the Scheduler's Logger.
+func (s *Scheduler) logReload(rs ReloadSummary) {
+	if l, ok := s.logger.(ReloadLogger); ok {
+		l.LogReload(rs)
+		return
+	}
+	if rs.Error != nil {
+		s.logger.Log(Status{Error: rs.Error, Start: rs.Time, End: rs.Time})
+	}
+}
+
+// scheduleFields returns only the fields of the configuration that determine
+// its schedule.
+func scheduleFields(jc JobConfig) JobConfig {
+	return JobConfig{
+		Cron:     jc.Cron,
+		Clock:    jc.Clock,
+		Weekdays: jc.Weekdays,
+		Every:    jc.Every,
+		Timezone: jc.Timezone,
+	}
+}
+
+// otherFields returns the fields of the configuration that do not determine
+// its schedule or where it was declared.
+func otherFields(jc JobConfig) JobConfig {
+	jc.Cron, jc.Clock, jc.Weekdays, jc.Every, jc.Timezone = "", nil, nil, "", ""
+	jc.Line = 0
+	return jc
+}
+
+// Reloader keeps the jobs of a Scheduler in sync with a configuration file.
+// On every reload, the file is diffed against the jobs the Reloader started:
+// new jobs are added, removed or disabled jobs are quit, jobs whose schedule
+// changed are rescheduled, and jobs with any other change are replaced.
+// Jobs that are no longer running are started again.
+type Reloader struct {
+	path      string
+	scheduler *Scheduler
+
+	mu      sync.Mutex
+	jobs    map[string]*Job
+	configs map[string]JobConfig
+	modTime time.Time
+	size    int64
+	quit    chan struct{}
+	stopped bool
+}
+
+// NewReloader creates a Reloader of the given configuration file for the
+// Scheduler. No jobs are started until it is reloaded.
+func NewReloader(s *Scheduler, path string) *Reloader {
+	return &Reloader{
+		path:      path,
+		scheduler: s,
+		jobs:      make(map[string]*Job),
+		configs:   make(map[string]JobConfig),
+		quit:      make(chan struct{}),
+	}
+}
+
+// Jobs returns the running jobs started by the Reloader, by name.
+func (r *Reloader) Jobs() map[string]*Job {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	jobs := make(map[string]*Job)
+	for name, job := range r.jobs {
+		if job.Running() {
+			jobs[name] = job
+		}
+	}
+	return jobs
+}
+
+// Reload reads the configuration file and applies its changes to the running
+// jobs. The summary is sent to the Scheduler's Logger. If the configuration
+// is invalid or any of its functions are not registered, the running jobs
+// are left unchanged and an error is returned.
+func (r *Reloader) Reload() (ReloadSummary, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rs := r.reload()
+	r.scheduler.logReload(rs)
+	return rs, rs.Error
+}
+
+// reload applies the configuration file. The lock must be held.
+func (r *Reloader) reload() ReloadSummary {
+	rs := ReloadSummary{Path: r.path, Time: time.Now()}
+
+	// Record the file's modification before reading it, so changes made
+	// while reading are reloaded again
+	if info, err := os.Stat(r.path); err == nil {
+		r.modTime, r.size = info.ModTime(), info.Size()
+	}
+	c, err := LoadConfig(r.path)
+	if err == nil {
+		err = r.scheduler.checkFuncs(c)
+	}
+	if err != nil {
+		rs.Error = err
+		return rs
+	}
+
+	enabled := make(map[string]JobConfig)
+	for _, jc := range c.Jobs {
+		if jc.IsEnabled() {
+			enabled[jc.Name] = jc
+		}
+	}
+
+	// Quit the jobs that were removed or disabled
+	for name, job := range r.jobs {
+		if _, ok := enabled[name]; !ok {
+			job.Quit()
+			delete(r.jobs, name)
+			delete(r.configs, name)
+			rs.Removed = append(rs.Removed, name)
+		}
+	}
+	sort.Strings(rs.Removed)
+
+	for _, jc := range c.Jobs {
+		if !jc.IsEnabled() {
+			continue
+		}
+		job, exists := r.jobs[jc.Name]
+		previous := r.configs[jc.Name]
+		r.configs[jc.Name] = jc
+
+		switch {
+		case !exists || !job.Running():
+			r.jobs[jc.Name] = r.scheduler.start(jc)
+			rs.Added = append(rs.Added, jc.Name)
+		case !reflect.DeepEqual(otherFields(previous), otherFields(jc)):
+			job.Quit()
+			r.jobs[jc.Name] = r.scheduler.start(jc)
+			rs.Replaced = append(rs.Replaced, jc.Name)
+		case !reflect.DeepEqual(scheduleFields(previous), scheduleFields(jc)):
+			schedule, _ := jc.Schedule()
+			if _, err := job.Reschedule(schedule); err != nil {
+				// The job stopped since it was checked
+				r.jobs[jc.Name] = r.scheduler.start(jc)
+				rs.Added = append(rs.Added, jc.Name)
+			} else {
+				rs.Rescheduled = append(rs.Rescheduled, jc.Name)
+			}
+		default:
+			rs.Unchanged += 1
+		}
+	}
+	return rs
+}
+
+// changed returns true if the configuration file was modified since it was
+// last reloaded.
+func (r *Reloader) changed() bool {
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return !info.ModTime().Equal(r.modTime) || info.Size() != r.size
+}
+
+// Poll checks the modification time of the configuration file at every
+// interval, and reloads it when it changes. Polling continues until the
+// Reloader is stopped.
+func (r *Reloader) Poll(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.quit:
+				return
+			case <-ticker.C:
+				if r.changed() {
+					r.Reload()
+				}
+			}
+		}
+	}()
+}
+
+// ReloadOn reloads the configuration file whenever one of the given signals
+// is received, or SIGHUP if none are given, until the Reloader is stopped.
+func (r *Reloader) ReloadOn(sigs ...os.Signal) {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{syscall.SIGHUP}
+	}
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, sigs...)
+	go func() {
+		defer signal.Stop(c)
+		for {
+			select {
+			case <-r.quit:
+				return
+			case <-c:
+				r.Reload()
+			}
+		}
+	}()
+}
+
+// Stop stops polling and handling signals. The running jobs are not quit.
+func (r *Reloader) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.stopped {
+		r.stopped = true
+		close(r.quit)
+	}
+}
+
+// Watch starts the jobs of the configuration file at the given path, then
+// reloads it whenever it changes, checking at every interval, or whenever a
+// SIGHUP is received.
+func (s *Scheduler) Watch(path string, interval time.Duration) (*Reloader, error) {
+	r := NewReloader(s, path)
+	if _, err := r.Reload(); err != nil {
+		return nil, err
+	}
+	r.Poll(interval)
+	r.ReloadOn()
+	return r, nil
+}
@@ -0,0 +1,137 @@
+package schedule
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// reloadLogger records the summaries of reloads.
+type reloadLogger struct {
+	sync.Mutex
+	summaries []ReloadSummary
+}
+
+func (l *reloadLogger) Log(s Status) {}
+
+func (l *reloadLogger) LogReload(rs ReloadSummary) {
+	l.Lock()
+	defer l.Unlock()
+	l.summaries = append(l.summaries, rs)
+}
+
+func (l *reloadLogger) count() int {
+	l.Lock()
+	defer l.Unlock()
+	return len(l.summaries)
+}
+
+func writeConfig(t *testing.T, path, text string) {
+	if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newReloadScheduler() (*Scheduler, *reloadLogger) {
+	s := New()
+	logger := &reloadLogger{}
+	s.SetLogger(logger)
+	noop := func() error { return nil }
+	for _, name := range []string{"a", "b", "c", "other"} {
+		s.Register(name, noop)
+	}
+	return s, logger
+}
+
+func TestReloader_Reload(t *testing.T) {
+	s, logger := newReloadScheduler()
+	path := filepath.Join(t.TempDir(), "jobs.conf")
+	writeConfig(t, path, "a every 1h\nb every 1h\n")
+
+	r := NewReloader(s, path)
+	rs, err := r.Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectString(t, rs.String(), "reloaded "+path+": added a, b; 0 unchanged")
+	expectInt(t, len(s.Jobs()), 2)
+	a := r.Jobs()["a"]
+
+	// Reloading without changes does nothing
+	rs, _ = r.Reload()
+	if rs.Changed() {
+		t.Errorf("Unexpected changes: %s", rs)
+	}
+	expectInt(t, rs.Unchanged, 2)
+
+	// Change the schedule of a, remove b, and add c
+	writeConfig(t, path, "c every 1h\n\na every 2h\n")
+	rs, err = r.Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectString(t, rs.String(), "reloaded "+path+": added c; removed b; rescheduled a; 0 unchanged")
+	if r.Jobs()["a"] != a {
+		t.Error("A rescheduled job should keep running")
+	}
+	expectString(t, a.description(), "every 2 hours")
+	expectInt(t, len(s.Jobs()), 2)
+
+	// Other changes replace the job, and disabled jobs are removed
+	writeConfig(t, path, "a every 2h func=other\nc every 1h enabled=false\n")
+	rs, _ = r.Reload()
+	expectString(t, rs.String(), "reloaded "+path+": removed c; replaced a; 0 unchanged")
+	if r.Jobs()["a"] == a || a.Running() {
+		t.Error("A replaced job should be quit and started again")
+	}
+
+	// Invalid configurations are not applied
+	writeConfig(t, path, "a every 2h\nd every 1h\n")
+	if _, err := r.Reload(); err == nil {
+		t.Error("A configuration with an unregistered function should not be applied")
+	}
+	expectInt(t, len(s.Jobs()), 1)
+
+	expectInt(t, logger.count(), 5)
+	if !strings.HasPrefix(logger.summaries[4].String(), "reload of "+path+" failed: line 2: d:") {
+		t.Errorf("Unexpected summary: %s", logger.summaries[4])
+	}
+
+	// Jobs that were quit are started again
+	r.Jobs()["a"].Quit()
+	writeConfig(t, path, "a every 2h func=other\n")
+	rs, _ = r.Reload()
+	expectString(t, rs.String(), "reloaded "+path+": added a; 0 unchanged")
+
+	for _, job := range s.Jobs() {
+		job.Quit()
+	}
+}
+
+func TestReloader_Poll(t *testing.T) {
+	s, logger := newReloadScheduler()
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	writeConfig(t, path, `{"a": {"every": "1h"}}`)
+
+	r, err := s.Watch(path, 5*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Stop()
+	expectInt(t, len(r.Jobs()), 1)
+
+	writeConfig(t, path, `{"a": {"every": "1h"}, "b": {"every": "1h"}}`)
+	for i := 0; i < 1000 && logger.count() < 2; i += 1 {
+		time.Sleep(time.Millisecond)
+	}
+	r.Stop()
+	expectInt(t, logger.count(), 2)
+	expectInt(t, len(r.Jobs()), 2)
+
+	for _, job := range s.Jobs() {
+		job.Quit()
+	}
+}
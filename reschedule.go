@@ -0,0 +1,41 @@
+package schedule
+
+import (
+	"time"
+)
+
+// scheduleRequest asks a job's iteration loop to replace its schedule.
+type scheduleRequest struct {
+	schedule Schedule
+	result   chan time.Time
+}
+
+// Reschedule replaces the schedule of a running job and returns the job's
+// new next run time. The job's splay, jitter, and active windows apply to
+// the new schedule. The schedule is replaced by the job's iteration loop,
+// so a run in progress will complete first. The number of iterations is
+// unchanged. If the new schedule has no next occurrence, the job stops.
+// ErrJobStopped is returned if the job is not running.
+func (j *Job) Reschedule(s Schedule) (time.Time, error) {
+	// Jobs that were never run have no iteration loop
+	if j.done == nil {
+		return time.Time{}, ErrJobStopped
+	}
+	req := scheduleRequest{schedule: s, result: make(chan time.Time, 1)}
+	select {
+	case j.schedules <- req:
+		return <-req.result, nil
+	case <-j.done:
+		return time.Time{}, ErrJobStopped
+	}
+}
+
+// setSchedule replaces the job's schedule and returns its next occurrence.
+// It must be called from the iteration loop.
+func (j *Job) setSchedule(s Schedule) time.Time {
+	j.schedule = j.constrain(s)
+	j.setDescription()
+
+	j.advance()
+	return j.Next()
+}
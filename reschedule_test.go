@@ -0,0 +1,58 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJob_Reschedule(t *testing.T) {
+	s := New()
+	j := s.Every(func() error { return nil }, time.Hour)
+
+	// Swap to a daily schedule
+	clocks := Clocks{MustParseClockUTC("3:00:00")}
+	before := time.Now()
+	next, err := j.Reschedule(clocks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectTime(t, next, clocks.Next(before))
+	expectTime(t, j.Next(), next)
+	expectString(t, j.description(), "every day at 3:00 UTC")
+
+	// Swap to an interval, which will run shortly
+	runs := make(chan bool, 1)
+	j.AddHooks(Hooks{AfterRun: func(*Job, Status) {
+		select {
+		case runs <- true:
+		default:
+		}
+	}})
+	if _, err = j.Reschedule(Interval(time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-runs:
+	case <-time.After(time.Second):
+		t.Fatal("The job should have run on its new schedule")
+	}
+
+	// A schedule without a next occurrence stops the job
+	next, err = j.Reschedule(Interval(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !next.IsZero() {
+		t.Errorf("Unexpected next time: %s", next)
+	}
+	s.WaitForJobsToFinish()
+	if _, err = j.Reschedule(clocks); err != ErrJobStopped {
+		t.Errorf("Unexpected error rescheduling a stopped job: %v", err)
+	}
+
+	// Jobs that were never run are not running
+	idle := s.schedule(niladic(func() error { return nil }), Interval(time.Hour))
+	if _, err = idle.Reschedule(clocks); err != ErrJobStopped {
+		t.Errorf("Unexpected error rescheduling a job that was never run: %v", err)
+	}
+}
@@ -0,0 +1,191 @@
+package schedule
+
+import (
+	"fmt"
+	"time"
+)
+
+// Schedule determines when a job runs.
+type Schedule interface {
+	// Next returns the first occurrence of the schedule strictly after the
+	// given time. A zero time indicates that there are no more occurrences.
+	Next(after time.Time) time.Time
+}
+
+// Reversible is implemented by schedules that can also compute their
+// previous occurrence, such as clocks and cron expressions.
+type Reversible interface {
+	// Prev returns the last occurrence of the schedule strictly before the
+	// given time. A zero time indicates that there are no earlier occurrences.
+	Prev(before time.Time) time.Time
+}
+
+// Describe returns a description of the given schedule. Schedules that
+// implement fmt.Stringer describe themselves.
+func Describe(s Schedule) string {
+	if stringer, ok := s.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return "on a custom schedule"
+}
+
+// Interval is a Schedule that occurs after every given duration.
+type Interval time.Duration
+
+// Next returns the time an interval after the given time. A non-positive
+// interval never occurs.
+func (i Interval) Next(after time.Time) time.Time {
+	if i <= 0 {
+		return time.Time{}
+	}
+	return after.Add(time.Duration(i))
+}
+
+func (i Interval) relative() bool {
+	return i > 0
+}
+
+// String returns a description such as "every 5 minutes".
+func (i Interval) String() string {
+	return fmt.Sprintf("every %s", describeDuration(time.Duration(i)))
+}
+
+type rate struct {
+	anchor time.Time
+	d      time.Duration
+}
+
+// IntervalFrom returns a Schedule that occurs at every multiple of the
+// duration before or after the anchor. Unlike an Interval, its occurrences
+// are fixed, so a late or long run does not delay the runs after it. A
+// non-positive duration never occurs.
+func IntervalFrom(anchor time.Time, d time.Duration) Schedule {
+	return rate{anchor, d}
+}
+
+// Next returns the first multiple of the duration from the anchor that is
+// strictly after the given time.
+func (r rate) Next(after time.Time) time.Time {
+	if r.d <= 0 {
+		return time.Time{}
+	}
+	n := after.Sub(r.anchor) / r.d
+	if after.Before(r.anchor) {
+		// Round towards the anchor's earlier multiples
+		n -= 1
+	}
+	next := r.anchor.Add(n * r.d)
+	for !next.After(after) {
+		next = next.Add(r.d)
+	}
+	return next
+}
+
+// String returns a description such as "every 5 minutes".
+func (r rate) String() string {
+	return Interval(r.d).String()
+}
+
+// never is a Schedule that never occurs.
+type never struct{}
+
+func (n never) Next(after time.Time) time.Time {
+	return time.Time{}
+}
+
+func (n never) String() string {
+	return "never"
+}
+
+// Never is a Schedule that never occurs. Combined with the Immediately
+// option, it runs a job once.
+var Never Schedule = never{}
+
+// At is a Schedule that occurs once at the given time.
+type At time.Time
+
+// Next returns the time if it is after the given time.
+func (a At) Next(after time.Time) time.Time {
+	if t := time.Time(a); t.After(after) {
+		return t
+	}
+	return time.Time{}
+}
+
+// String returns a description such as "once at 2014-02-14 03:00:00 UTC".
+func (a At) String() string {
+	return fmt.Sprintf("once at %s", time.Time(a).Format("2006-01-02 15:04:05 MST"))
+}
+
+// DayClocks is a Schedule that occurs on every combination of its weekdays
+// and clocks. Each clock occurs on the weekdays of its own location.
+type DayClocks struct {
+	Days   []time.Weekday
+	Clocks []Clock
+}
+
+// Next returns the first combination of weekday and clock strictly after the
+// given time. It never occurs if there are no weekdays or clocks.
+func (dc DayClocks) Next(after time.Time) time.Time {
+	var next time.Time
+	for _, clock := range dc.Clocks {
+		year, month, day := after.In(clock.loc).Date()
+
+		// Every weekday occurs within the next week
+		for d := 0; d <= 7; d += 1 {
+			t := clock.ToTime(year, month, day+d)
+			if !t.After(after) || !dc.hasDay(t.Weekday()) {
+				continue
+			}
+			if next.IsZero() || t.Before(next) {
+				next = t
+			}
+			break
+		}
+	}
+	return next
+}
+
+// Prev returns the last combination of weekday and clock strictly before the
+// given time. It never occurs if there are no weekdays or clocks.
+func (dc DayClocks) Prev(before time.Time) time.Time {
+	var prev time.Time
+	for _, clock := range dc.Clocks {
+		year, month, day := before.In(clock.loc).Date()
+
+		// Every weekday occurred within the last week
+		for d := 0; d <= 7; d += 1 {
+			t := clock.ToTime(year, month, day-d)
+			if !t.Before(before) || !dc.hasDay(t.Weekday()) {
+				continue
+			}
+			if prev.IsZero() || t.After(prev) {
+				prev = t
+			}
+			break
+		}
+	}
+	return prev
+}
+
+func (dc DayClocks) hasDay(weekday time.Weekday) bool {
+	for _, d := range dc.Days {
+		if d == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// Describe returns a description such as "Mon–Fri at 9:00 and 17:00".
+func (dc DayClocks) Describe(f HourFormat) string {
+	if len(dc.Days) == 0 || len(dc.Clocks) == 0 {
+		return "never"
+	}
+	return fmt.Sprintf("%s at %s", describeDays(dc.Days), describeClocks(dc.Clocks, f))
+}
+
+// String returns a description on a 24-hour clock.
+func (dc DayClocks) String() string {
+	return dc.Describe(Hour24)
+}
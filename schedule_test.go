@@ -0,0 +1,178 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+// countdown is a custom Schedule that occurs every millisecond until it has
+// occurred the given number of times.
+type countdown struct {
+	remaining chan int
+}
+
+func (c countdown) Next(after time.Time) time.Time {
+	n := <-c.remaining
+	c.remaining <- n - 1
+	if n <= 0 {
+		return time.Time{}
+	}
+	return after.Add(time.Millisecond)
+}
+
+func TestScheduler_Schedule(t *testing.T) {
+	s := New()
+
+	// A custom schedule runs until it has no next occurrence
+	remaining := make(chan int, 1)
+	remaining <- 3
+	assertJob := newTestJob(t, 3)
+	j := s.Schedule(assertJob.Increment, countdown{remaining}, Named("custom"))
+	expectString(t, j.Name, "custom")
+	expectString(t, j.description(), "on a custom schedule")
+	s.WaitForJobsToFinish()
+	assertJob.Assert()
+
+	// Options can run immediately and limit the number of runs
+	assertJob = newTestJob(t, 2)
+	j = s.Schedule(assertJob.Increment, Interval(time.Millisecond), Immediately(), Times(2))
+	expectString(t, j.description(), "immediately, then every 1ms, 2 times")
+	s.WaitForJobsToFinish()
+	assertJob.Assert()
+
+	// Zero runs will not run at all
+	assertJob = newTestJob(t, 0)
+	s.RepeatN(assertJob.Increment, time.Millisecond, 0)
+	s.WaitForJobsToFinish()
+	assertJob.Assert()
+
+	expectString(t, s.Now(func() error { return nil }).description(), "once, immediately")
+	s.WaitForJobsToFinish()
+}
+
+func TestScheduler_Every(t *testing.T) {
+	// Every runs at a fixed rate, so a late run does not move the next
+	s := New()
+	j := s.Every(func() error { return nil }, time.Hour)
+	defer j.Quit()
+	first := j.Next()
+	expectTime(t, j.schedule.Next(first.Add(40*time.Minute)), first.Add(time.Hour))
+}
+
+func TestSchedules(t *testing.T) {
+	now := time.Date(2014, time.Month(2), 14, 12, 0, 0, 0, time.UTC)
+
+	expectTime(t, Interval(time.Minute).Next(now), now.Add(time.Minute))
+	if !Interval(0).Next(now).IsZero() {
+		t.Error("A zero interval should never occur")
+	}
+
+	// Intervals from an anchor occur at fixed multiples of the duration
+	fixed := IntervalFrom(now, time.Hour)
+	expectTime(t, fixed.Next(now), now.Add(time.Hour))
+	expectTime(t, fixed.Next(now.Add(90*time.Minute)), now.Add(2*time.Hour))
+	expectTime(t, fixed.Next(now.Add(-90*time.Minute)), now.Add(-time.Hour))
+	expectTime(t, fixed.Next(now.Add(-time.Hour)), now)
+	expectString(t, Describe(fixed), "every hour")
+	if !IntervalFrom(now, 0).Next(now).IsZero() {
+		t.Error("A zero interval should never occur")
+	}
+	if !Never.Next(now).IsZero() {
+		t.Error("Never should never occur")
+	}
+
+	at := At(now.Add(time.Hour))
+	expectTime(t, at.Next(now), now.Add(time.Hour))
+	if !at.Next(now.Add(time.Hour)).IsZero() {
+		t.Error("At should only occur once")
+	}
+	expectString(t, at.String(), "once at 2014-02-14 13:00:00 UTC")
+}
+
+func TestDayClocks(t *testing.T) {
+	// 2014-02-14 was a Friday
+	friday := time.Date(2014, time.Month(2), 14, 12, 0, 0, 0, time.UTC)
+	dc := DayClocks{
+		Days:   []time.Weekday{time.Monday, time.Friday},
+		Clocks: []Clock{MustParseClockUTC("9:00:00"), MustParseClockUTC("17:00:00")},
+	}
+	expectString(t, dc.String(), "Mon and Fri at 9:00 and 17:00 UTC")
+
+	next := dc.Next(friday)
+	expectTime(t, next, time.Date(2014, time.Month(2), 14, 17, 0, 0, 0, time.UTC))
+	next = dc.Next(next)
+	expectTime(t, next, time.Date(2014, time.Month(2), 17, 9, 0, 0, 0, time.UTC))
+
+	// A single weekday occurs a week later at the same clock
+	weekly := DayClocks{[]time.Weekday{time.Friday}, []Clock{MustParseClockUTC("12:00:00")}}
+	expectTime(t, weekly.Next(friday), friday.AddDate(0, 0, 7))
+
+	if !(DayClocks{Clocks: dc.Clocks}).Next(friday).IsZero() {
+		t.Error("No weekdays should never occur")
+	}
+}
+
+func TestDayClocks_DST(t *testing.T) {
+	denver, err := time.LoadLocation("America/Denver")
+	if err != nil {
+		t.Skip(err)
+	}
+	// Clocks fall back on Sunday 2014-11-02, a day of 25 hours
+	dc := DayClocks{[]time.Weekday{time.Sunday, time.Monday}, []Clock{MustParseClockIn("12:00:00", denver)}}
+	saturday := time.Date(2014, time.Month(11), 1, 12, 0, 0, 0, denver)
+	sunday := dc.Next(saturday)
+	expectTime(t, sunday, time.Date(2014, time.Month(11), 2, 12, 0, 0, 0, denver))
+	expectTime(t, dc.Next(sunday), time.Date(2014, time.Month(11), 3, 12, 0, 0, 0, denver))
+}
+
+func TestDayClocks_Prev(t *testing.T) {
+	// 2014-02-14 was a Friday
+	friday := time.Date(2014, time.Month(2), 14, 12, 0, 0, 0, time.UTC)
+	dc := DayClocks{Workweek, []Clock{MustParseClockUTC("9:00:00"), MustParseClockUTC("17:00:00")}}
+	expectTime(t, dc.Prev(friday), time.Date(2014, time.Month(2), 14, 9, 0, 0, 0, time.UTC))
+
+	// The given time itself is excluded
+	prev := dc.Prev(time.Date(2014, time.Month(2), 17, 9, 0, 0, 0, time.UTC))
+	expectTime(t, prev, time.Date(2014, time.Month(2), 14, 17, 0, 0, 0, time.UTC))
+
+	if !(DayClocks{Clocks: dc.Clocks}).Prev(friday).IsZero() {
+		t.Error("No weekdays should never occur")
+	}
+}
+
+// TestReversible checks that the previous occurrence of every occurrence is
+// the one before it, across both daylight saving time changes.
+func TestReversible(t *testing.T) {
+	denver := loadDenver(t)
+	cron, err := ParseCronIn("30 1,2 * * *", denver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock := MustParseClockIn("2:30:00", denver)
+	daytime := FromDayAndClock(time.Sunday, MustParseClockIn("2:30:00", denver))
+	ticker := DaysAndClocksTicker(Weekends, []Clock{MustParseClockIn("1:30:00", denver)})
+	clocks := Clocks{MustParseClockIn("1:30:00", denver), MustParseClockIn("12:00:00", denver)}
+	schedules := []struct {
+		Schedule
+		reversed Reversible
+	}{
+		{clock.Schedule(), clock},
+		{daytime.Schedule(), daytime},
+		{ticker.Schedule(), ticker},
+		{clocks, clocks},
+		{cron, cron},
+	}
+	for _, start := range []time.Time{
+		time.Date(2014, time.Month(3), 1, 0, 0, 0, 0, denver),
+		time.Date(2014, time.Month(10), 25, 0, 0, 0, 0, denver),
+	} {
+		for i, s := range schedules {
+			times := NextN(s, start, 30)
+			for j := len(times) - 1; j > 0; j -= 1 {
+				if prev := s.reversed.Prev(times[j]); !prev.Equal(times[j-1]) {
+					t.Errorf("Unexpected previous occurrence of schedule %d: %s != %s", i, prev, times[j-1])
+				}
+			}
+		}
+	}
+}
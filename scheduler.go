@@ -1,130 +1,131 @@
 package schedule
 
 import (
+	"context"
+	"sort"
 	"sync"
 	"time"
 )
 
-// Scheduler contains a wait group of all unfinished jobs on the Scheduler and
-// an optional Logger.
+// Scheduler contains a wait group of all unfinished jobs on the Scheduler, an
+// optional Logger, and an optional Tracer.
 type Scheduler struct {
 	unfinished sync.WaitGroup
 	logger     Logger
-}
+	metrics    *Metrics
+	tracer     Tracer
+	limiter    limiter
 
-// TODO Options
-// * Specify if the job should start immediately
-// * Register the job on the scheduler for reporting
+	mu         sync.Mutex
+	hooks      []Hooks
+	middleware []Middleware
+	blackouts  []blackout
+	funcs      map[string]JobFunc
+	jobs       map[int]*Job
+	lastID     int
+}
 
 // Run the job whenever a tick is received on the time channel
-func (s *Scheduler) whenever(exec func() error, tick <-chan time.Time) *Job {
+func (s *Scheduler) whenever(exec JobFunc, tick <-chan time.Time, opts ...Option) *Job {
 	job := &Job{
 		exec:      exec,
 		quit:      make(chan bool),
 		tick:      tick,
-		n:         1,
 		scheduler: s,
 	}
+	for _, opt := range opts {
+		opt(job)
+	}
+	job.setDescription()
 	return job
 }
 
 // Whenever will run the job whenever the job's ticker ticks.
-func (s *Scheduler) Whenever(exec func() error, tick <-chan time.Time) *Job {
-	job := s.whenever(exec, tick)
-	job.Run()
-	return job
+func (s *Scheduler) Whenever(exec func() error, tick <-chan time.Time, opts ...Option) *Job {
+	return s.WheneverCtx(niladic(exec), tick, opts...)
 }
 
-// Every will run the job after every tick of the given duration.
-func (s *Scheduler) Every(exec func() error, d time.Duration) *Job {
-	return Whenever(exec, time.Tick(d))
+// WheneverCtx will run the context-aware job whenever the job's ticker ticks.
+func (s *Scheduler) WheneverCtx(exec JobFunc, tick <-chan time.Time, opts ...Option) *Job {
+	job := s.whenever(exec, tick, opts...)
+	job.Run()
+	return job
 }
 
-// Now will run the the job immediately once.
-func (s *Scheduler) Now(exec func() error) *Job {
+// schedule creates a job that runs on the given schedule and applies the
+// options. The job is not started.
+func (s *Scheduler) schedule(exec JobFunc, schedule Schedule, opts ...Option) *Job {
 	job := &Job{
 		exec:      exec,
 		quit:      make(chan bool),
-		tick:      time.After(0),
-		setter:    func() <-chan time.Time { return time.After(0) },
-		n:         1,
-		increment: 1,
+		schedule:  schedule,
 		scheduler: s,
 	}
+	for _, opt := range opts {
+		opt(job)
+	}
+	job.schedule = job.constrain(schedule)
+	job.setDescription()
+	return job
+}
+
+// Schedule will run the job on every occurrence of the given schedule until
+// the schedule has no next occurrence.
+func (s *Scheduler) Schedule(exec func() error, schedule Schedule, opts ...Option) *Job {
+	return s.ScheduleCtx(niladic(exec), schedule, opts...)
+}
+
+// ScheduleCtx will run the context-aware job on every occurrence of the
+// given schedule until the schedule has no next occurrence.
+func (s *Scheduler) ScheduleCtx(exec JobFunc, schedule Schedule, opts ...Option) *Job {
+	job := s.schedule(exec, schedule, opts...)
 	job.Run()
 	return job
 }
 
+// Every will run the job after every interval of the given duration. Runs
+// occur at a fixed rate from the time the job starts, so a long run does not
+// delay the runs after it. Runs that would overlap a run in progress are
+// skipped.
+func (s *Scheduler) Every(exec func() error, d time.Duration, opts ...Option) *Job {
+	return s.Schedule(exec, IntervalFrom(time.Now(), d), opts...)
+}
+
+// Now will run the the job immediately once.
+func (s *Scheduler) Now(exec func() error, opts ...Option) *Job {
+	return s.Schedule(exec, Never, append([]Option{Immediately()}, opts...)...)
+}
+
 // Repeat runs the job immediately, then repeats the job forever while waiting
 // the given duration between iterations.
-func (s *Scheduler) Repeat(exec func() error, wait time.Duration) *Job {
-	job := &Job{
-		exec:      exec,
-		quit:      make(chan bool),
-		tick:      time.After(0),
-		setter:    func() <-chan time.Time { return time.After(wait) },
-		n:         1,
-		scheduler: s,
-	}
-	job.Run()
-	return job
+func (s *Scheduler) Repeat(exec func() error, wait time.Duration, opts ...Option) *Job {
+	return s.Schedule(exec, Interval(wait), append([]Option{Immediately()}, opts...)...)
 }
 
 // RepeatN runs the job immediately, then repeats the job the given number
 // of times, waiting the given duration between iterations.
-func (s *Scheduler) RepeatN(exec func() error, wait time.Duration, n int) *Job {
-	job := &Job{
-		exec:      exec,
-		quit:      make(chan bool),
-		tick:      time.After(0),
-		setter:    func() <-chan time.Time { return time.After(wait) },
-		n:         n,
-		increment: 1,
-		scheduler: s,
-	}
-	job.Run()
-	return job
+func (s *Scheduler) RepeatN(exec func() error, wait time.Duration, n int, opts ...Option) *Job {
+	return s.Schedule(exec, Interval(wait), append([]Option{Immediately(), Times(n)}, opts...)...)
 }
 
 // Daily runs the job once a day at the given clock.
-func (s *Scheduler) Daily(exec func() error, clock Clock) *Job {
-	// Determine the next time the given clock will occur
-	next := func() <-chan time.Time { return TickAt(clock.Next()) }
-	job := &Job{
-		exec:      exec,
-		quit:      make(chan bool),
-		tick:      next(),
-		setter:    next,
-		n:         1,
-		scheduler: s,
-	}
-	job.Run()
-	return job
+func (s *Scheduler) Daily(exec func() error, clock Clock, opts ...Option) *Job {
+	return s.Schedule(exec, Clocks{clock}, opts...)
 }
 
 // Weekly runs the job on the given weekday and clock.
-func (s *Scheduler) Weekly(exec func() error, d time.Weekday, c Clock) *Job {
-	// Create the ticker and start immediately
-	ticker := DayClockTicker(d, c)
-	ticker.Start()
-
-	// Create a job that runs on every tick
-	job := s.whenever(exec, ticker.C)
-	job.Run()
-	return job
+func (s *Scheduler) Weekly(exec func() error, d time.Weekday, c Clock, opts ...Option) *Job {
+	return s.Schedule(exec, DayClocks{[]time.Weekday{d}, []Clock{c}}, opts...)
 }
 
 // DaysAndClocks runs the job on every given combination of the given
 // weekdays and clocks.
-func (s *Scheduler) DaysAndClocks(exec func() error, ds []time.Weekday, cs []Clock) *Job {
-	// Create the ticker
-	ticker := DaysAndClocksTicker(ds, cs)
-	ticker.Start()
-
-	// Create a job that runs on every tick
-	job := s.whenever(exec, ticker.C)
-	job.Run()
-	return job
+func (s *Scheduler) DaysAndClocks(exec func() error, ds []time.Weekday, cs []Clock, opts ...Option) *Job {
+	// Days and clocks may be given out of order, sort copies of them
+	days := uniqueDays(sortedWeekdays(ds))
+	clocks := append([]Clock(nil), cs...)
+	SortClocks(clocks)
+	return s.Schedule(exec, DayClocks{days, clocks}, opts...)
 }
 
 // WaitForJobsToFinish will wait for all the jobs on the scheduler to finish
@@ -135,15 +136,121 @@ func (s *Scheduler) WaitForJobsToFinish() error {
 	return nil
 }
 
+// Shutdown quits every job on the Scheduler and waits for their runs in
+// progress to finish. It returns the context's error if the context is done
+// before every job has quit.
+func (s *Scheduler) Shutdown(ctx context.Context) error {
+	for _, job := range s.Jobs() {
+		go job.Quit()
+	}
+	done := make(chan struct{})
+	go func() {
+		s.unfinished.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // SetLogger allows the Scheduler's Logger to be set.
 func (s *Scheduler) SetLogger(l Logger) {
 	s.logger = l
 }
 
-// New creats a new Scheduler with a default logger.
+// SetMetrics will record the runs of every job on the Scheduler to the given
+// Metrics.
+func (s *Scheduler) SetMetrics(m *Metrics) {
+	s.metrics = m
+}
+
+// SetTracer allows the Scheduler's Tracer to be set. A span will be opened
+// around every job run.
+func (s *Scheduler) SetTracer(t Tracer) {
+	s.tracer = t
+}
+
+// AddHooks adds lifecycle hooks that are called for every job on the
+// Scheduler.
+func (s *Scheduler) AddHooks(h Hooks) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hooks = append(s.hooks, h)
+}
+
+// Use adds middleware around the function of every job on the Scheduler.
+// The first middleware added is the outermost.
+func (s *Scheduler) Use(mws ...Middleware) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.middleware = append(s.middleware, mws...)
+}
+
+// lifecycle returns copies of the Scheduler's hooks and middleware.
+func (s *Scheduler) lifecycle() ([]Hooks, []Middleware) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Hooks(nil), s.hooks...), append([]Middleware(nil), s.middleware...)
+}
+
+// register adds the job to the Scheduler's registry of running jobs. The job
+// is assigned an identifier if it does not have one.
+func (s *Scheduler) register(j *Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if j.id == 0 {
+		s.lastID += 1
+		j.id = s.lastID
+	}
+	if s.jobs == nil {
+		s.jobs = make(map[int]*Job)
+	}
+	s.jobs[j.id] = j
+}
+
+// unregister removes the job from the Scheduler's registry.
+func (s *Scheduler) unregister(j *Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.jobs, j.id)
+}
+
+// Jobs returns all running jobs on the Scheduler ordered by their ID.
+func (s *Scheduler) Jobs() []*Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	jobs := make([]*Job, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		jobs = append(jobs, j)
+	}
+	sort.Sort(jobsByID(jobs))
+	return jobs
+}
+
+// Job returns the running job with the given ID, or nil if there is no such
+// job.
+func (s *Scheduler) Job(id int) *Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.jobs[id]
+}
+
+// jobsByID implements the `sort.Interface` for jobs
+type jobsByID []*Job
+
+func (js jobsByID) Len() int           { return len(js) }
+func (js jobsByID) Swap(i, j int)      { js[i], js[j] = js[j], js[i] }
+func (js jobsByID) Less(i, j int) bool { return js[i].id < js[j].id }
+
+// New creats a new Scheduler with a default logger and a no-op tracer.
 func New() *Scheduler {
 	return &Scheduler{
-		logger: &DefaultLogger{},
+		logger:  &DefaultLogger{},
+		tracer:  NoopTracer{},
+		limiter: limiter{now: defaultNow},
 	}
 }
 
@@ -151,48 +258,55 @@ var std = New()
 
 // Whenever will run the job on the default scheduler whenever the job's
 // ticker ticks.
-func Whenever(exec func() error, tick <-chan time.Time) *Job {
-	return std.Whenever(exec, tick)
+func Whenever(exec func() error, tick <-chan time.Time, opts ...Option) *Job {
+	return std.Whenever(exec, tick, opts...)
+}
+
+// WheneverCtx will run the context-aware job on the default scheduler
+// whenever the job's ticker ticks.
+func WheneverCtx(exec JobFunc, tick <-chan time.Time, opts ...Option) *Job {
+	return std.WheneverCtx(exec, tick, opts...)
 }
 
-// Every will run the job after every tick of the given duration.
-func Every(exec func() error, d time.Duration) *Job {
-	return std.Every(exec, d)
+// Every will run the job on the default scheduler after every interval of
+// the given duration.
+func Every(exec func() error, d time.Duration, opts ...Option) *Job {
+	return std.Every(exec, d, opts...)
 }
 
 // Now will run the the job immediately once on the default scheduler.
-func Now(exec func() error) *Job {
-	return std.Now(exec)
+func Now(exec func() error, opts ...Option) *Job {
+	return std.Now(exec, opts...)
 }
 
 // Repeat runs the job immediately on the default scheduler,
 // then repeats the job forever while waiting the given duration between
 // iterations.
-func Repeat(exec func() error, wait time.Duration) *Job {
-	return std.Repeat(exec, wait)
+func Repeat(exec func() error, wait time.Duration, opts ...Option) *Job {
+	return std.Repeat(exec, wait, opts...)
 }
 
 // RepeatN runs the job immediately on the default scheduler, then repeats the
 // job the given number of times, waiting the given duration between
 // iterations.
-func RepeatN(exec func() error, wait time.Duration, n int) *Job {
-	return std.RepeatN(exec, wait, n)
+func RepeatN(exec func() error, wait time.Duration, n int, opts ...Option) *Job {
+	return std.RepeatN(exec, wait, n, opts...)
 }
 
 // Daily runs the job on the default scheduler once a day at the given clock.
-func Daily(exec func() error, clock Clock) *Job {
-	return std.Daily(exec, clock)
+func Daily(exec func() error, clock Clock, opts ...Option) *Job {
+	return std.Daily(exec, clock, opts...)
 }
 
 // Weekly runs the job on the default scheduler on the given weekday and clock.
-func Weekly(exec func() error, weekday time.Weekday, clock Clock) *Job {
-	return std.Weekly(exec, weekday, clock)
+func Weekly(exec func() error, weekday time.Weekday, clock Clock, opts ...Option) *Job {
+	return std.Weekly(exec, weekday, clock, opts...)
 }
 
 // DaysAndClocks runs the job on the default scheduler on every given
 // combination of the given weekdays and clocks.
-func DaysAndClocks(exec func() error, ds []time.Weekday, cs []Clock) *Job {
-	return std.DaysAndClocks(exec, ds, cs)
+func DaysAndClocks(exec func() error, ds []time.Weekday, cs []Clock, opts ...Option) *Job {
+	return std.DaysAndClocks(exec, ds, cs, opts...)
 }
 
 // WaitForJobsToFinish will wait for all the jobs on the default scheduler to
@@ -200,5 +314,3 @@ func DaysAndClocks(exec func() error, ds []time.Weekday, cs []Clock) *Job {
 func WaitForJobsToFinish() error {
 	return std.WaitForJobsToFinish()
 }
-
-// TODO Method to tell all jobs to quit as soon as possible for clean shutdown
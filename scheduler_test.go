@@ -1,6 +1,7 @@
 package schedule
 
 import (
+	"context"
 	"testing"
 	"time"
 )
@@ -36,3 +37,29 @@ func TestScheduler(t *testing.T) {
 	// These will require some form of clock manipulation, just test that the
 	// struct fields were set correctly?
 }
+
+func TestScheduler_Shutdown(t *testing.T) {
+	s := New()
+	release := make(chan bool)
+	started := make(chan bool)
+	s.Repeat(func() error {
+		started <- true
+		<-release
+		return nil
+	}, time.Hour)
+	s.Every(func() error { return nil }, time.Hour)
+	<-started
+
+	// The run in progress keeps the shutdown waiting
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected the shutdown to time out, got %v", err)
+	}
+
+	release <- true
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	expectInt(t, len(s.Jobs()), 0)
+}
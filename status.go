@@ -6,17 +6,28 @@ import (
 )
 
 // Status records the start and end time of a task. It will include the
-// task's error message if one occurred.
+// task's error message if one occurred. Skipped is true if the task did not
+// run, such as when its job was paused. Wait is the time the task spent waiting
+// for a slot within its scheduler's concurrency limits before it started.
+// Jobs that run a Command also record its output and exit code.
 type Status struct {
-	Error error
-	Start time.Time
-	End   time.Time
+	Error    error
+	Start    time.Time
+	End      time.Time
+	Wait     time.Duration
+	Skipped  bool
+	Stdout   string
+	Stderr   string
+	ExitCode int
 }
 
 // String returns a basic string with the task's elapsed time and error
 // message if one occurred.
 func (s Status) String() string {
 	// TODO Are the casts needed?
+	if s.Skipped {
+		return "SKIPPED"
+	}
 	elapsed := float64(s.End.Sub(s.Start).Nanoseconds()) / float64(time.Millisecond)
 	if s.Error == nil {
 		return fmt.Sprintf("OK (%.3f ms)", elapsed)
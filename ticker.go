@@ -49,7 +49,7 @@ func (ticker *Ticker) Start() {
 			d %= len(ticker.days)
 			day := ticker.days[d]
 
-			for c = c; c < len(ticker.clocks); c += 1 {
+			for ; c < len(ticker.clocks); c += 1 {
 				nextClock := ticker.clocks[c]
 
 				// Build the next time from this day and clock
@@ -108,6 +108,29 @@ func uniqueDays(a []time.Weekday) []time.Weekday {
 	return unique
 }
 
+// Prev returns the time of the last tick strictly before the given time,
+// whether or not the ticker was running.
+func (ticker *Ticker) Prev(before time.Time) time.Time {
+	return DayClocks{ticker.days, ticker.clocks}.Prev(before)
+}
+
+// Schedule returns a Schedule that occurs on every tick of the ticker, so
+// its ticks can be computed without starting it.
+func (ticker *Ticker) Schedule() Schedule {
+	return DayClocks{ticker.days, ticker.clocks}
+}
+
+// Describe returns a description of when the ticker ticks, such as
+// "Mon–Fri at 9:00 and 17:00".
+func (ticker *Ticker) Describe(f HourFormat) string {
+	return DayClocks{ticker.days, ticker.clocks}.Describe(f)
+}
+
+// String returns a description on a 24-hour clock.
+func (ticker *Ticker) String() string {
+	return ticker.Describe(Hour24)
+}
+
 // DayClockTicker creates a new Ticker that ticks at the time of day specified
 // by the clock and for every day in the days array.
 func DayClockTicker(weekday time.Weekday, clock Clock) *Ticker {
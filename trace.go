@@ -0,0 +1,148 @@
+package schedule
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Attribute keys set on the span of every job run.
+const (
+	AttrJobName      = "job.name"
+	AttrJobScheduled = "job.scheduled"
+	AttrJobAttempt   = "job.attempt"
+)
+
+// Attribute is a key-value pair that describes a span.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Span is a single traced operation. It must be ended once the operation
+// has completed.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Tracer is an interface for opening spans. Every job run is traced as a
+// root span. Adapters for tracing libraries such as OpenTelemetry can
+// implement this interface.
+type Tracer interface {
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+type spanKey struct{}
+
+// ContextWithSpan returns a copy of the context that carries the given span.
+func ContextWithSpan(ctx context.Context, span Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// SpanFromContext returns the span carried by the context. A no-op span is
+// returned if the context does not carry a span.
+func SpanFromContext(ctx context.Context) Span {
+	if span, ok := ctx.Value(spanKey{}).(Span); ok {
+		return span
+	}
+	return noopSpan{}
+}
+
+// NoopTracer is an implementation of the Tracer interface that does nothing.
+// It is the default Tracer of a Scheduler.
+type NoopTracer struct{}
+
+// Start returns the given context and a span that does nothing.
+func (t NoopTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (s noopSpan) SetAttributes(attrs ...Attribute) {}
+func (s noopSpan) RecordError(err error)            {}
+func (s noopSpan) End()                             {}
+
+// RecordedSpan is a span that was opened by a RecordingTracer.
+type RecordedSpan struct {
+	Name       string
+	Attributes []Attribute
+	Errors     []error
+	Start      time.Time
+	End        time.Time
+	Ended      bool
+}
+
+// Attribute returns the value of the attribute with the given key, or nil
+// if the attribute was not set.
+func (s RecordedSpan) Attribute(key string) interface{} {
+	for i := len(s.Attributes) - 1; i >= 0; i -= 1 {
+		if s.Attributes[i].Key == key {
+			return s.Attributes[i].Value
+		}
+	}
+	return nil
+}
+
+// RecordingTracer is an implementation of the Tracer interface that keeps
+// every span in memory. It is intended for tests.
+type RecordingTracer struct {
+	mu    sync.Mutex
+	spans []*RecordedSpan
+}
+
+// Start opens a span that will be recorded by the tracer.
+func (t *RecordingTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	span := &RecordedSpan{
+		Name:       name,
+		Attributes: append([]Attribute(nil), attrs...),
+		Start:      time.Now(),
+	}
+	t.spans = append(t.spans, span)
+	return ctx, &recordingSpan{tracer: t, span: span}
+}
+
+// Spans returns a copy of every span opened by the tracer in the order they
+// were started.
+func (t *RecordingTracer) Spans() []RecordedSpan {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	spans := make([]RecordedSpan, len(t.spans))
+	for i, span := range t.spans {
+		spans[i] = *span
+		spans[i].Attributes = append([]Attribute(nil), span.Attributes...)
+		spans[i].Errors = append([]error(nil), span.Errors...)
+	}
+	return spans
+}
+
+// recordingSpan guards its span with the mutex of its tracer.
+type recordingSpan struct {
+	tracer *RecordingTracer
+	span   *RecordedSpan
+}
+
+func (s *recordingSpan) SetAttributes(attrs ...Attribute) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.span.Attributes = append(s.span.Attributes, attrs...)
+}
+
+func (s *recordingSpan) RecordError(err error) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.span.Errors = append(s.span.Errors, err)
+}
+
+func (s *recordingSpan) End() {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	if !s.span.Ended {
+		s.span.End = time.Now()
+		s.span.Ended = true
+	}
+}
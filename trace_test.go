@@ -0,0 +1,63 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTracer(t *testing.T) {
+	s := New()
+	tracer := &RecordingTracer{}
+	s.SetTracer(tracer)
+
+	// The run's span should be carried by its context
+	var count int
+	tick := make(chan time.Time)
+	j := s.whenever(func(ctx context.Context) error {
+		count += 1
+		if _, ok := SpanFromContext(ctx).(*recordingSpan); !ok {
+			t.Error("The run's context should carry a recorded span")
+		}
+		return errors.New("failed")
+	}, tick)
+	j.Name = "traced"
+	j.Run()
+
+	scheduled := time.Date(2014, time.Month(2), 14, 3, 0, 0, 0, time.UTC)
+	tick <- scheduled
+	j.Quit()
+	s.WaitForJobsToFinish()
+	expectInt(t, count, 1)
+
+	spans := tracer.Spans()
+	if len(spans) != 1 {
+		t.Fatalf("Unexpected number of spans: %d != 1", len(spans))
+	}
+	span := spans[0]
+	expectString(t, span.Name, "traced")
+	if !span.Ended {
+		t.Error("The span should have ended")
+	}
+	if span.Attribute(AttrJobName) != "traced" {
+		t.Errorf("Unexpected job name attribute: %v", span.Attribute(AttrJobName))
+	}
+	if span.Attribute(AttrJobScheduled) != scheduled {
+		t.Errorf("Unexpected scheduled attribute: %v", span.Attribute(AttrJobScheduled))
+	}
+	if span.Attribute(AttrJobAttempt) != 1 {
+		t.Errorf("Unexpected attempt attribute: %v", span.Attribute(AttrJobAttempt))
+	}
+	if len(span.Errors) != 1 {
+		t.Errorf("Unexpected number of span errors: %d != 1", len(span.Errors))
+	}
+}
+
+func TestSpanFromContext(t *testing.T) {
+	// A context without a span should return a usable no-op span
+	span := SpanFromContext(context.Background())
+	span.SetAttributes(Attribute{"key", "value"})
+	span.RecordError(errors.New("ignored"))
+	span.End()
+}
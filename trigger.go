@@ -0,0 +1,58 @@
+package schedule
+
+import (
+	"context"
+)
+
+// triggerRequest asks a job's iteration loop for an extra run.
+type triggerRequest struct {
+	ctx    context.Context
+	result chan Status
+}
+
+// Trigger runs the job immediately and returns the resulting Status. The run
+// is performed by the job's iteration loop, so it waits for any run in
+// progress to complete and is logged, traced, and hooked like any other run.
+// The job's schedule is not changed. ErrJobStopped is returned if the job is
+// not running.
+func (j *Job) Trigger() (Status, error) {
+	return j.TriggerCtx(context.Background())
+}
+
+// TriggerCtx runs the job immediately like Trigger. The given context is the
+// parent of the run's context. If the context is done before the run
+// completes, its error is returned and the run continues without waiting.
+func (j *Job) TriggerCtx(ctx context.Context) (Status, error) {
+	// Jobs that were never run have no iteration loop
+	if j.done == nil {
+		return Status{}, ErrJobStopped
+	}
+	req := triggerRequest{ctx: ctx, result: make(chan Status, 1)}
+	select {
+	case j.triggers <- req:
+	case <-j.done:
+		return Status{}, ErrJobStopped
+	case <-ctx.Done():
+		return Status{}, ctx.Err()
+	}
+	select {
+	case status := <-req.result:
+		return status, nil
+	case <-ctx.Done():
+		return Status{}, ctx.Err()
+	}
+}
+
+// SetCountTriggers sets whether triggered runs count against the job's
+// number of iterations, such as the n of RepeatN. By default they do not.
+func (j *Job) SetCountTriggers(count bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.countTriggers = count
+}
+
+func (j *Job) countsTriggers() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.countTriggers
+}
@@ -0,0 +1,77 @@
+package schedule
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type ctxKey struct{}
+
+func TestJob_Trigger(t *testing.T) {
+	s := New()
+	var count int
+	j := s.RepeatN(func() error {
+		count += 1
+		return nil
+	}, time.Hour, 2)
+
+	// Triggered runs do not count against the iterations
+	for i := 0; i < 3; i += 1 {
+		status, err := j.Trigger()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status.Error != nil || status.Start.IsZero() {
+			t.Errorf("Unexpected triggered status: %v", status)
+		}
+	}
+	if !j.Running() {
+		t.Fatal("The job should still be running")
+	}
+
+	// Once requested, the next trigger completes the final iteration
+	j.SetCountTriggers(true)
+	if _, err := j.Trigger(); err != nil {
+		t.Fatal(err)
+	}
+	s.WaitForJobsToFinish()
+	expectInt(t, count, 5)
+	expectInt(t, len(j.History()), 5)
+
+	if _, err := j.Trigger(); err != ErrJobStopped {
+		t.Errorf("Unexpected error triggering a stopped job: %v", err)
+	}
+
+	// Jobs that were never run are not running
+	idle := s.schedule(niladic(func() error { return nil }), Interval(time.Hour))
+	if _, err := idle.Trigger(); err != ErrJobStopped {
+		t.Errorf("Unexpected error triggering a job that was never run: %v", err)
+	}
+}
+
+func TestJob_TriggerCtx(t *testing.T) {
+	s := New()
+	var value interface{}
+	j := s.WheneverCtx(func(ctx context.Context) error {
+		value = ctx.Value(ctxKey{})
+		return nil
+	}, make(chan time.Time))
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+	if _, err := j.TriggerCtx(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if value != "value" {
+		t.Error("The run's context should descend from the trigger's")
+	}
+
+	// A done context either returns its error or was accepted by the job
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := j.TriggerCtx(ctx); err != nil && err != context.Canceled {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	j.Quit()
+	s.WaitForJobsToFinish()
+}
@@ -0,0 +1,223 @@
+package schedule
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// AlertKind is the reason a Watchdog raised an alert.
+type AlertKind int
+
+const (
+	// AlertLate is raised when a scheduled run has not started within the
+	// grace period after its scheduled time.
+	AlertLate AlertKind = iota
+
+	// AlertOverrun is raised when a run has not finished within its
+	// expected duration.
+	AlertOverrun
+)
+
+// String returns the name of the kind, such as "late".
+func (k AlertKind) String() string {
+	if k == AlertOverrun {
+		return "overrun"
+	}
+	return "late"
+}
+
+// Alert is raised by a Watchdog when a job appears to have stopped. Expected
+// is the time by which the run should have started or finished.
+type Alert struct {
+	Job      *Job
+	Kind     AlertKind
+	Expected time.Time
+	Time     time.Time
+}
+
+// Error returns a description of the alert, so that it can be logged as the
+// error of a Status.
+func (a Alert) Error() string {
+	name := a.Job.Name
+	if name == "" {
+		name = fmt.Sprintf("job %d", a.Job.ID())
+	}
+	verb := "start"
+	if a.Kind == AlertOverrun {
+		verb = "finish"
+	}
+	return fmt.Sprintf(
+		"%s is %s: its run was expected to %s by %s",
+		name, a.Kind, verb, a.Expected.Format("2006-01-02 15:04:05 MST"),
+	)
+}
+
+// String returns the same description as Error.
+func (a Alert) String() string {
+	return a.Error()
+}
+
+// AlertLogger is a Logger that can also log alerts. Schedulers with a Logger
+// that does not implement AlertLogger log a Status with the alert as its
+// error instead.
+type AlertLogger interface {
+	Logger
+	LogAlert(Alert)
+}
+
+// raise sends the alert to the Scheduler's Logger and to the OnAlert hooks
+// of the alert's job.
+func (s *Scheduler) raise(a Alert) {
+	if l, ok := s.logger.(AlertLogger); ok {
+		l.LogAlert(a)
+	} else {
+		s.logger.Log(Status{Error: a, Start: a.Time, End: a.Time})
+	}
+	hooks, _ := a.Job.lifecycle()
+	for _, h := range hooks {
+		h.onAlert(a.Job, a)
+	}
+}
+
+// ExpectedDuration sets how long a run of the job is expected to take. A
+// Watchdog raises an alert if a run is still in progress after it.
+func ExpectedDuration(d time.Duration) Option {
+	return func(j *Job) {
+		j.expected = d
+	}
+}
+
+// alertKey identifies the alerts raised for a single run of a job.
+type alertKey struct {
+	job  *Job
+	kind AlertKind
+}
+
+// Watchdog checks the running jobs of a Scheduler for runs that have not
+// started within a grace period after their scheduled time, or have not
+// finished within their expected duration. Each late or overrunning run is
+// alerted once, through the Scheduler's Logger and the OnAlert hooks.
+type Watchdog struct {
+	scheduler *Scheduler
+	grace     time.Duration
+	duration  time.Duration
+	now       func() time.Time
+
+	mu      sync.Mutex
+	alerted map[alertKey]time.Time
+	quit    chan struct{}
+	stopped bool
+}
+
+// NewWatchdog creates a Watchdog of the Scheduler's jobs. Runs are expected
+// to finish within the given duration unless their job has its own
+// ExpectedDuration. A zero duration only checks jobs with their own.
+func NewWatchdog(s *Scheduler, grace, duration time.Duration) *Watchdog {
+	return &Watchdog{
+		scheduler: s,
+		grace:     grace,
+		duration:  duration,
+		now:       defaultNow,
+		alerted:   make(map[alertKey]time.Time),
+		quit:      make(chan struct{}),
+	}
+}
+
+// check returns the alert for the job, if any.
+func (w *Watchdog) check(j *Job, now time.Time) (Alert, bool) {
+	j.mu.Lock()
+	next, started, lastStart, expected := j.next, j.started, j.lastStart, j.expected
+	j.mu.Unlock()
+
+	if !started.IsZero() {
+		if expected <= 0 {
+			expected = w.duration
+		}
+		if end := started.Add(expected); expected > 0 && now.After(end) {
+			return Alert{Job: j, Kind: AlertOverrun, Expected: end, Time: now}, true
+		}
+		return Alert{}, false
+	}
+	// Jobs ticked by an external channel have no scheduled time, and a run
+	// that has started is not late while the job advances past it
+	if !next.IsZero() && !next.After(lastStart) {
+		return Alert{}, false
+	}
+	if start := next.Add(w.grace); !next.IsZero() && now.After(start) {
+		return Alert{Job: j, Kind: AlertLate, Expected: start, Time: now}, true
+	}
+	return Alert{}, false
+}
+
+// Check checks every running job once and raises an alert for each that is
+// late or overrunning, unless the same run was already alerted. The raised
+// alerts are returned.
+func (w *Watchdog) Check() []Alert {
+	now := w.now()
+	jobs := w.scheduler.Jobs()
+
+	w.mu.Lock()
+	var alerts []Alert
+	running := make(map[*Job]bool)
+	for _, j := range jobs {
+		running[j] = true
+		a, ok := w.check(j, now)
+		if !ok {
+			continue
+		}
+		key := alertKey{j, a.Kind}
+		if last, ok := w.alerted[key]; ok && last.Equal(a.Expected) {
+			continue
+		}
+		w.alerted[key] = a.Expected
+		alerts = append(alerts, a)
+	}
+
+	// Forget the jobs that have stopped
+	for key := range w.alerted {
+		if !running[key.job] {
+			delete(w.alerted, key)
+		}
+	}
+	w.mu.Unlock()
+
+	for _, a := range alerts {
+		w.scheduler.raise(a)
+	}
+	return alerts
+}
+
+// Poll checks the jobs at every interval until the Watchdog is stopped.
+func (w *Watchdog) Poll(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-w.quit:
+				return
+			case <-ticker.C:
+				w.Check()
+			}
+		}
+	}()
+}
+
+// Stop stops polling. Stopping a Watchdog more than once does nothing.
+func (w *Watchdog) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.stopped {
+		w.stopped = true
+		close(w.quit)
+	}
+}
+
+// StartWatchdog creates a Watchdog of the Scheduler's jobs and checks them
+// at every interval. See NewWatchdog for the grace period and duration.
+func (s *Scheduler) StartWatchdog(grace, duration, interval time.Duration) *Watchdog {
+	w := NewWatchdog(s, grace, duration)
+	w.Poll(interval)
+	return w
+}
@@ -0,0 +1,138 @@
+package schedule
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// alertLogger records alerts and the errors of statuses.
+type alertLogger struct {
+	sync.Mutex
+	alerts []Alert
+	errors []error
+}
+
+func (l *alertLogger) Log(s Status) {
+	l.Lock()
+	defer l.Unlock()
+	if s.Error != nil {
+		l.errors = append(l.errors, s.Error)
+	}
+}
+
+func (l *alertLogger) LogAlert(a Alert) {
+	l.Lock()
+	defer l.Unlock()
+	l.alerts = append(l.alerts, a)
+}
+
+func TestWatchdog_Late(t *testing.T) {
+	s := New()
+	logger := &alertLogger{}
+	s.SetLogger(logger)
+	var hooked []Alert
+	s.AddHooks(Hooks{OnAlert: func(j *Job, a Alert) { hooked = append(hooked, a) }})
+
+	j := s.Every(func() error { return nil }, time.Hour, Named("sync"))
+	defer j.Quit()
+	next := j.Next()
+
+	w := NewWatchdog(s, time.Minute, 0)
+	w.now = func() time.Time { return next.Add(30 * time.Second) }
+	expectInt(t, len(w.Check()), 0)
+
+	w.now = func() time.Time { return next.Add(2 * time.Minute) }
+	alerts := w.Check()
+	expectInt(t, len(alerts), 1)
+	if alerts[0].Job != j || alerts[0].Kind != AlertLate {
+		t.Errorf("Unexpected alert: %+v", alerts[0])
+	}
+	expectTime(t, alerts[0].Expected, next.Add(time.Minute))
+	expectString(t, alerts[0].Error(), "sync is late: its run was expected to start by "+
+		next.Add(time.Minute).Format("2006-01-02 15:04:05 MST"))
+
+	// The same run is only alerted once
+	expectInt(t, len(w.Check()), 0)
+	expectInt(t, len(logger.alerts), 1)
+	expectInt(t, len(hooked), 1)
+}
+
+func TestWatchdog_FinishedRun(t *testing.T) {
+	// A run that started on time is not late while its hooks run
+	s := New()
+	s.SetLogger(&alertLogger{})
+	w := NewWatchdog(s, 50*time.Millisecond, 0)
+	checked := make(chan []Alert, 1)
+	s.AddHooks(Hooks{AfterRun: func(j *Job, status Status) { checked <- w.Check() }})
+
+	j := s.RepeatN(func() error {
+		time.Sleep(200 * time.Millisecond)
+		return nil
+	}, time.Hour, 1)
+	defer j.Quit()
+	expectInt(t, len(<-checked), 0)
+}
+
+func TestWatchdog_Overrun(t *testing.T) {
+	s := New()
+	s.SetLogger(&alertLogger{})
+	stuck, startedA, releaseA := newBlockingJob(s, "stuck", ExpectedDuration(time.Second))
+	slow, startedB, releaseB := newBlockingJob(s, "slow")
+	defer stuck.Quit()
+	defer slow.Quit()
+	resultA, resultB := triggerAsync(stuck), triggerAsync(slow)
+	<-startedA
+	<-startedB
+
+	// Only jobs with an expected duration are checked by default
+	w := NewWatchdog(s, time.Minute, 0)
+	w.now = func() time.Time { return time.Now().Add(2 * time.Second) }
+	alerts := w.Check()
+	expectInt(t, len(alerts), 1)
+	if alerts[0].Job != stuck || alerts[0].Kind != AlertOverrun {
+		t.Errorf("Unexpected alert: %+v", alerts[0])
+	}
+
+	releaseA <- true
+	releaseB <- true
+	<-resultA
+	<-resultB
+	expectInt(t, len(w.Check()), 0)
+}
+
+func TestWatchdog_Logger(t *testing.T) {
+	// Loggers that do not log alerts receive a status with the alert
+	s := New()
+	var logged []Status
+	s.SetLogger(loggerFunc(func(status Status) { logged = append(logged, status) }))
+
+	j, started, release := newBlockingJob(s, "stuck")
+	defer j.Quit()
+	result := triggerAsync(j)
+	<-started
+
+	w := NewWatchdog(s, time.Minute, time.Second)
+	w.now = func() time.Time { return time.Now().Add(time.Minute) }
+	alerts := w.Check()
+	release <- true
+	<-result
+
+	expectInt(t, len(alerts), 1)
+	var alert Alert
+	if len(logged) == 0 || !errors.As(logged[0].Error, &alert) || alert.Kind != AlertOverrun {
+		t.Errorf("Unexpected logged statuses: %+v", logged)
+	}
+
+	w.Poll(time.Millisecond)
+	w.Stop()
+	w.Stop()
+}
+
+// loggerFunc adapts a function to the Logger interface.
+type loggerFunc func(Status)
+
+func (f loggerFunc) Log(s Status) {
+	f(s)
+}
@@ -1,7 +1,9 @@
 package schedule
 
 import (
+	"fmt"
 	"sort"
+	"strings"
 	"time"
 )
 
@@ -43,6 +45,13 @@ func SortWeekdays(weekdays []time.Weekday) {
 	sort.Sort(Weekdays(weekdays))
 }
 
+// sortedWeekdays returns a sorted copy of the given weekdays.
+func sortedWeekdays(weekdays []time.Weekday) []time.Weekday {
+	sorted := append([]time.Weekday(nil), weekdays...)
+	SortWeekdays(sorted)
+	return sorted
+}
+
 // Days away returns the number of days between the current day and the
 // next occurrence if the given Weekday.
 func DaysAway(weekday time.Weekday) int {
@@ -56,3 +65,16 @@ func daysAway(now func() time.Time, weekday time.Weekday) int {
 	}
 	return days
 }
+
+// ParseWeekday parses the full or three letter English name of a weekday,
+// ignoring case, such as "Monday" or "mon".
+func ParseWeekday(value string) (time.Weekday, error) {
+	name := strings.ToLower(value)
+	for d := time.Sunday; d <= time.Saturday; d += 1 {
+		full := strings.ToLower(d.String())
+		if name == full || name == full[:3] {
+			return d, nil
+		}
+	}
+	return time.Sunday, fmt.Errorf("schedule: unknown weekday %q", value)
+}
@@ -15,3 +15,18 @@ func TestDaysAway(t *testing.T) {
 	expectInt(t, daysAway(setTuesday, time.Sunday), 5)
 	expectInt(t, daysAway(setTuesday, time.Monday), 6)
 }
+
+func TestParseWeekday(t *testing.T) {
+	for _, value := range []string{"Monday", "mon", "MON", "monday"} {
+		day, err := ParseWeekday(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if day != time.Monday {
+			t.Errorf("Unexpected weekday for %q: %s", value, day)
+		}
+	}
+	if _, err := ParseWeekday("mo"); err == nil {
+		t.Error("Expected an error parsing an unknown weekday")
+	}
+}
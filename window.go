@@ -0,0 +1,175 @@
+package schedule
+
+import (
+	"fmt"
+	"time"
+)
+
+// secondsPerDay is the number of seconds in a day without daylight saving
+// time changes.
+const secondsPerDay = 60 * 60 * 24
+
+// Window is a range of time each day from its start clock until its end
+// clock. If the end is before the start, the window crosses midnight. The
+// window is restricted to starting on the given weekdays, or every day if
+// there are none. Times are compared in the location of the start clock.
+type Window struct {
+	Start Clock
+	End   Clock
+	Days  []time.Weekday
+}
+
+// NewWindow creates a Window from the start clock until the end clock on the
+// given weekdays, or every day if no weekdays are given.
+func NewWindow(start, end Clock, days ...time.Weekday) Window {
+	return Window{Start: start, End: end, Days: days}
+}
+
+// crossesMidnight returns true if the window ends on the day after it starts.
+func (w Window) crossesMidnight() bool {
+	return w.End.sec%secondsPerDay < w.Start.sec%secondsPerDay
+}
+
+func (w Window) hasDay(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, d := range w.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// start returns the start of the window that contains the given time, and
+// whether the time is contained by the window.
+func (w Window) start(t time.Time) (time.Time, bool) {
+	local := t.In(w.Start.loc)
+	sec := ClockFromTime(local).sec
+	start := w.Start.sec % secondsPerDay
+	end := w.End.sec % secondsPerDay
+	year, month, day := local.Date()
+
+	switch {
+	case start == end:
+		// An empty window contains nothing
+		return time.Time{}, false
+	case !w.crossesMidnight():
+		if sec < start || sec >= end {
+			return time.Time{}, false
+		}
+	case sec < end:
+		// The window started the day before
+		day -= 1
+	case sec < start:
+		return time.Time{}, false
+	}
+	began := w.Start.ToTime(year, month, day)
+	return began, w.hasDay(began.Weekday())
+}
+
+// Contains returns true if the given time is within the window. Since its
+// signature matches, it can be used as a Condition.
+func (w Window) Contains(t time.Time) bool {
+	_, ok := w.start(t)
+	return ok
+}
+
+// EndOf returns the end of the window that contains the given time. It
+// returns a zero time if the time is not within the window.
+func (w Window) EndOf(t time.Time) time.Time {
+	began, ok := w.start(t)
+	if !ok {
+		return time.Time{}
+	}
+	year, month, day := began.Date()
+	if w.crossesMidnight() {
+		day += 1
+	}
+	return w.End.ToTime(year, month, day)
+}
+
+// Describe returns a description such as "8:00–18:00 UTC on Sat and Sun".
+func (w Window) Describe(f HourFormat) string {
+	desc := withZone(fmt.Sprintf("%s–%s", w.Start.Format(f), w.End.Format(f)), w.Start.loc)
+	if len(w.Days) == 0 {
+		return desc
+	}
+	return fmt.Sprintf("%s on %s", desc, describeDays(w.Days))
+}
+
+// String returns a description on a 24-hour clock.
+func (w Window) String() string {
+	return w.Describe(Hour24)
+}
+
+// Windows are a set of windows.
+type Windows []Window
+
+// Contains returns true if any of the windows contain the given time.
+func (ws Windows) Contains(t time.Time) bool {
+	for _, w := range ws {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// EndOf returns the latest end of the windows that contain the given time.
+// It returns a zero time if no window contains the time.
+func (ws Windows) EndOf(t time.Time) time.Time {
+	var end time.Time
+	for _, w := range ws {
+		if e := w.EndOf(t); e.After(end) {
+			end = e
+		}
+	}
+	return end
+}
+
+// Describe returns the descriptions of the windows as a list.
+func (ws Windows) Describe(f HourFormat) string {
+	descs := make([]string, len(ws))
+	for i, w := range ws {
+		descs[i] = w.Describe(f)
+	}
+	return joinList(descs)
+}
+
+// String returns a description on a 24-hour clock.
+func (ws Windows) String() string {
+	return ws.Describe(Hour24)
+}
+
+type during struct {
+	schedule Schedule
+	windows  Windows
+}
+
+// During returns a Schedule of the occurrences of the given schedule that
+// fall within any of the windows.
+func During(s Schedule, windows ...Window) Schedule {
+	return during{s, windows}
+}
+
+func (d during) Next(after time.Time) time.Time {
+	return filter{schedule: d.schedule, include: d.windows.Contains}.Next(after)
+}
+
+func (d during) Describe(f HourFormat) string {
+	return fmt.Sprintf("%s, during %s", DescribeWith(d.schedule, f), d.windows.Describe(f))
+}
+
+func (d during) String() string {
+	return d.Describe(Hour24)
+}
+
+// ActiveDuring restricts the runs of the job to the given windows. The
+// occurrences of the job's schedule outside of the windows are dropped.
+func ActiveDuring(windows ...Window) Option {
+	return func(j *Job) {
+		j.windows = append(j.windows, windows...)
+	}
+}
@@ -0,0 +1,98 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWindow(t *testing.T) {
+	business := NewWindow(MustParseClockUTC("8:00:00"), MustParseClockUTC("18:00:00"))
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2014, time.Month(2), day, hour, min, 0, 0, time.UTC)
+	}
+
+	if !business.Contains(at(14, 8, 0)) || !business.Contains(at(14, 17, 59)) {
+		t.Error("The window should contain its start and the times before its end")
+	}
+	if business.Contains(at(14, 7, 59)) || business.Contains(at(14, 18, 0)) {
+		t.Error("The window should not contain times before its start or at its end")
+	}
+	expectTime(t, business.EndOf(at(14, 12, 0)), at(14, 18, 0))
+	if !business.EndOf(at(14, 20, 0)).IsZero() {
+		t.Error("The end of a window that does not contain the time should be zero")
+	}
+	expectString(t, business.String(), "8:00–18:00 UTC")
+
+	// Windows can be restricted to weekdays. February 14th, 2014 was a Friday.
+	weekend := NewWindow(MustParseClockUTC("8:00:00"), MustParseClockUTC("18:00:00"), time.Saturday, time.Sunday)
+	if weekend.Contains(at(14, 12, 0)) || !weekend.Contains(at(15, 12, 0)) {
+		t.Error("The window should only contain times on its weekdays")
+	}
+	expectString(t, weekend.String(), "8:00–18:00 UTC on Sun and Sat")
+
+	// An empty window contains nothing
+	if NewWindow(MustParseClockUTC("8:00:00"), MustParseClockUTC("8:00:00")).Contains(at(14, 8, 0)) {
+		t.Error("An empty window should contain nothing")
+	}
+}
+
+func TestWindow_CrossesMidnight(t *testing.T) {
+	// The window starts on Friday night and ends on Saturday morning
+	night := NewWindow(MustParseClockUTC("22:00:00"), MustParseClockUTC("2:00:00"), time.Friday)
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2014, time.Month(2), day, hour, min, 0, 0, time.UTC)
+	}
+
+	if !night.Contains(at(14, 23, 0)) || !night.Contains(at(15, 1, 0)) {
+		t.Error("The window should contain times on both sides of midnight")
+	}
+	if night.Contains(at(14, 1, 0)) || night.Contains(at(15, 23, 0)) {
+		t.Error("The window should only contain times of windows starting on Friday")
+	}
+	if night.Contains(at(15, 2, 0)) || night.Contains(at(14, 21, 59)) {
+		t.Error("The window should not contain times outside of its clocks")
+	}
+	expectTime(t, night.EndOf(at(14, 23, 0)), at(15, 2, 0))
+	expectTime(t, night.EndOf(at(15, 1, 0)), at(15, 2, 0))
+}
+
+func TestWindow_Location(t *testing.T) {
+	denver := loadDenver(t)
+	business := NewWindow(MustParseClockIn("8:00:00", denver), MustParseClockIn("18:00:00", denver))
+
+	// 16:00 UTC is 09:00 in Denver during standard time
+	if !business.Contains(time.Date(2014, time.Month(2), 14, 16, 0, 0, 0, time.UTC)) {
+		t.Error("The window should compare times in the location of its clocks")
+	}
+	if business.Contains(time.Date(2014, time.Month(2), 14, 14, 0, 0, 0, time.UTC)) {
+		t.Error("The window should compare times in the location of its clocks")
+	}
+}
+
+func TestDuring(t *testing.T) {
+	business := NewWindow(MustParseClockUTC("8:00:00"), MustParseClockUTC("18:00:00"))
+	s := During(Interval(4*time.Hour), business)
+	at := func(day, hour int) time.Time {
+		return time.Date(2014, time.Month(2), day, hour, 0, 0, 0, time.UTC)
+	}
+
+	expectOccurrences(t, s, at(14, 6),
+		at(14, 10),
+		at(14, 14),
+		at(15, 10), // Occurrences at 18:00, 22:00, 02:00, and 06:00 are dropped
+	)
+	expectString(t, Describe(s), "every 4 hours, during 8:00–18:00 UTC")
+}
+
+func TestActiveDuring(t *testing.T) {
+	s := New()
+	business := NewWindow(MustParseClockUTC("8:00:00"), MustParseClockUTC("18:00:00"))
+	j := s.schedule(niladic(func() error { return nil }), Clocks{MustParseClockUTC("7:00:00"), MustParseClockUTC("9:00:00")}, ActiveDuring(business))
+
+	after := time.Date(2014, time.Month(2), 14, 0, 0, 0, 0, time.UTC)
+	expectOccurrences(t, j.schedule, after,
+		time.Date(2014, time.Month(2), 14, 9, 0, 0, 0, time.UTC),
+		time.Date(2014, time.Month(2), 15, 9, 0, 0, 0, time.UTC),
+	)
+	expectString(t, j.description(), "every day at 7:00 and 9:00 UTC, during 8:00–18:00 UTC")
+}
@@ -0,0 +1,278 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FailurePolicy determines what happens to the remaining steps of a workflow
+// once one of its steps fails.
+type FailurePolicy int
+
+const (
+	// FailFast cancels the context of running steps and skips every step
+	// that has not yet started.
+	FailFast FailurePolicy = iota
+
+	// ContinueOnFailure keeps running the steps that do not depend on the
+	// failed step.
+	ContinueOnFailure
+)
+
+// step is a single function of a workflow and the names of the steps it
+// depends on.
+type step struct {
+	name  string
+	exec  JobFunc
+	after []string
+}
+
+// Workflow is a directed acyclic graph of steps. Each step runs once all of
+// the steps it depends on have succeeded, and steps without dependencies
+// between them run concurrently. A step is skipped if any of its
+// dependencies fail or are skipped. Workflows are scheduled with the
+// Scheduler's Workflow method.
+type Workflow struct {
+	Name   string
+	policy FailurePolicy
+	steps  []step
+}
+
+// NewWorkflow creates an empty Workflow with the given name and the FailFast
+// policy.
+func NewWorkflow(name string) *Workflow {
+	return &Workflow{Name: name}
+}
+
+// Step adds a step to the workflow that runs after the named steps succeed.
+// The named steps may be added later.
+func (w *Workflow) Step(name string, exec func() error, after ...string) {
+	w.StepCtx(name, niladic(exec), after...)
+}
+
+// StepCtx adds a context-aware step to the workflow that runs after the
+// named steps succeed.
+func (w *Workflow) StepCtx(name string, exec JobFunc, after ...string) {
+	w.steps = append(w.steps, step{name, exec, after})
+}
+
+// SetFailurePolicy sets what happens when a step fails. The default is
+// FailFast.
+func (w *Workflow) SetFailurePolicy(p FailurePolicy) {
+	w.policy = p
+}
+
+// Validate returns an error if the workflow has duplicate steps, depends on
+// a step that does not exist, or has a cycle.
+func (w *Workflow) Validate() error {
+	index := make(map[string]int)
+	for i, s := range w.steps {
+		if _, exists := index[s.name]; exists {
+			return fmt.Errorf("schedule: workflow %s has duplicate step %s", w.Name, s.name)
+		}
+		index[s.name] = i
+	}
+	for _, s := range w.steps {
+		for _, dep := range s.after {
+			if _, exists := index[dep]; !exists {
+				return fmt.Errorf("schedule: step %s of workflow %s depends on unknown step %s", s.name, w.Name, dep)
+			}
+		}
+	}
+
+	// Depth first search for a step that is reached again while its own
+	// dependencies are being visited
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(w.steps))
+	var path []string
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case visiting:
+			// Report the cycle from the first occurrence of the step
+			start := 0
+			for path[start] != w.steps[i].name {
+				start += 1
+			}
+			cycle := append(append([]string(nil), path[start:]...), w.steps[i].name)
+			return fmt.Errorf("schedule: workflow %s has a cycle: %s", w.Name, strings.Join(cycle, " -> "))
+		case visited:
+			return nil
+		}
+		state[i] = visiting
+		path = append(path, w.steps[i].name)
+		for _, dep := range w.steps[i].after {
+			if err := visit(index[dep]); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = visited
+		return nil
+	}
+	for i := range w.steps {
+		if err := visit(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StepStatus is the Status of a single step of a workflow run.
+type StepStatus struct {
+	Name string
+	Status
+}
+
+// WorkflowStatus records a single run of a workflow and the statuses of its
+// steps, in the order the steps were added. Error is set if the workflow is
+// invalid or any of its steps failed.
+type WorkflowStatus struct {
+	Name  string
+	Error error
+	Start time.Time
+	End   time.Time
+	Steps []StepStatus
+}
+
+// String returns a summary line of the workflow run followed by a line for
+// every step.
+func (ws WorkflowStatus) String() string {
+	var ok, failed, skipped int
+	lines := make([]string, len(ws.Steps))
+	for i, s := range ws.Steps {
+		switch {
+		case s.Skipped:
+			skipped += 1
+		case s.Error != nil:
+			failed += 1
+		default:
+			ok += 1
+		}
+		lines[i] = fmt.Sprintf("\t%s: %s", s.Name, s.Status)
+	}
+	result := "OK"
+	if ws.Error != nil {
+		result = "ERROR: " + ws.Error.Error()
+	}
+	summary := fmt.Sprintf(
+		"workflow %s: %s (%d ok, %d failed, %d skipped)",
+		ws.Name, result, ok, failed, skipped,
+	)
+	return strings.Join(append([]string{summary}, lines...), "\n")
+}
+
+// Run runs every step of the workflow once and returns its status. Steps are
+// passed a context that is cancelled if the workflow fails fast.
+func (w *Workflow) Run(parent context.Context) WorkflowStatus {
+	status := WorkflowStatus{Name: w.Name, Start: time.Now()}
+	if status.Error = w.Validate(); status.Error != nil {
+		status.End = time.Now()
+		return status
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	index := make(map[string]int)
+	done := make([]chan struct{}, len(w.steps))
+	for i, s := range w.steps {
+		index[s.name] = i
+		done[i] = make(chan struct{})
+	}
+	status.Steps = make([]StepStatus, len(w.steps))
+
+	var mu sync.Mutex
+	var failed []string
+	var wg sync.WaitGroup
+	for i, s := range w.steps {
+		wg.Add(1)
+		go func(i int, s step) {
+			defer wg.Done()
+			defer close(done[i])
+
+			// Wait for every dependency. Their statuses are complete once
+			// they are done.
+			ready := true
+			for _, dep := range s.after {
+				<-done[index[dep]]
+				upstream := status.Steps[index[dep]]
+				if upstream.Skipped || upstream.Error != nil {
+					ready = false
+				}
+			}
+			mu.Lock()
+			if len(failed) > 0 && w.policy == FailFast {
+				ready = false
+			}
+			mu.Unlock()
+
+			result := StepStatus{Name: s.name, Status: Status{Start: time.Now()}}
+			if ready {
+				result.Error = s.exec(ctx)
+			} else {
+				result.Skipped = true
+			}
+			result.End = time.Now()
+			status.Steps[i] = result
+
+			if result.Error != nil {
+				mu.Lock()
+				failed = append(failed, s.name)
+				mu.Unlock()
+				if w.policy == FailFast {
+					cancel()
+				}
+			}
+		}(i, s)
+	}
+	wg.Wait()
+
+	status.End = time.Now()
+	if len(failed) > 0 {
+		status.Error = fmt.Errorf("failed steps: %s", strings.Join(failed, ", "))
+	}
+	return status
+}
+
+// WorkflowLogger is a Logger that can also log the status of workflow runs.
+// Schedulers with a Logger that does not implement WorkflowLogger log the
+// Status of every step instead.
+type WorkflowLogger interface {
+	Logger
+	LogWorkflow(WorkflowStatus)
+}
+
+// logWorkflow sends the status of a workflow run to the Scheduler's Logger.
+func (s *Scheduler) logWorkflow(ws WorkflowStatus) {
+	if l, ok := s.logger.(WorkflowLogger); ok {
+		l.LogWorkflow(ws)
+		return
+	}
+	for _, step := range ws.Steps {
+		s.logger.Log(step.Status)
+	}
+}
+
+// Workflow runs every step of the workflow on every occurrence of the given
+// schedule. The status of every run is sent to the Scheduler's Logger. The
+// job is named after the workflow unless another name is given. An error is
+// returned if the workflow is invalid.
+func (s *Scheduler) Workflow(w *Workflow, schedule Schedule, opts ...Option) (*Job, error) {
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+	exec := func(ctx context.Context) error {
+		ws := w.Run(ctx)
+		s.logWorkflow(ws)
+		return ws.Error
+	}
+	return s.ScheduleCtx(exec, schedule, append([]Option{Named(w.Name)}, opts...)...), nil
+}
@@ -0,0 +1,190 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// pipeline records the order in which its steps run.
+type pipeline struct {
+	sync.Mutex
+	order []string
+}
+
+func (p *pipeline) step(name string, err error) func() error {
+	return func() error {
+		p.Lock()
+		defer p.Unlock()
+		p.order = append(p.order, name)
+		return err
+	}
+}
+
+func (p *pipeline) ran() string {
+	p.Lock()
+	defer p.Unlock()
+	return strings.Join(p.order, ",")
+}
+
+// workflowLogger records the statuses of workflow runs.
+type workflowLogger struct {
+	sync.Mutex
+	runs []WorkflowStatus
+}
+
+func (l *workflowLogger) Log(s Status) {}
+
+func (l *workflowLogger) LogWorkflow(ws WorkflowStatus) {
+	l.Lock()
+	defer l.Unlock()
+	l.runs = append(l.runs, ws)
+}
+
+func TestWorkflow_Run(t *testing.T) {
+	p := &pipeline{}
+	w := NewWorkflow("nightly")
+
+	// Steps may be added before their dependencies
+	w.Step("load", p.step("load", nil), "transform")
+	w.Step("transform", p.step("transform", nil), "extract")
+	w.Step("extract", p.step("extract", nil))
+
+	status := w.Run(context.Background())
+	if status.Error != nil {
+		t.Fatal(status.Error)
+	}
+	expectString(t, p.ran(), "extract,transform,load")
+	expectInt(t, len(status.Steps), 3)
+	expectString(t, status.Steps[0].Name, "load")
+	if !strings.HasPrefix(status.String(), "workflow nightly: OK (3 ok, 0 failed, 0 skipped)") {
+		t.Errorf("Unexpected workflow status: %s", status)
+	}
+}
+
+func TestWorkflow_FailFast(t *testing.T) {
+	p := &pipeline{}
+	w := NewWorkflow("nightly")
+
+	// The failure happens once the concurrent step has started
+	started := make(chan bool)
+	fail := p.step("extract", errors.New("no data"))
+	w.Step("extract", func() error {
+		<-started
+		return fail()
+	})
+	w.Step("transform", p.step("transform", nil), "extract")
+
+	// A concurrent step is cancelled, and the steps after it are skipped
+	w.StepCtx("audit", func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return nil
+	})
+	w.Step("report", p.step("report", nil), "audit")
+
+	status := w.Run(context.Background())
+	expectString(t, p.ran(), "extract")
+	if status.Error == nil {
+		t.Fatal("The workflow should fail")
+	}
+	expectString(t, status.Error.Error(), "failed steps: extract")
+	if !status.Steps[1].Skipped || status.Steps[2].Skipped || !status.Steps[3].Skipped {
+		t.Errorf("Unexpected skipped steps: %s", status)
+	}
+}
+
+func TestWorkflow_ContinueOnFailure(t *testing.T) {
+	p := &pipeline{}
+	w := NewWorkflow("nightly")
+	w.SetFailurePolicy(ContinueOnFailure)
+	w.Step("extract", p.step("extract", errors.New("no data")))
+	w.Step("transform", p.step("transform", nil), "extract")
+	w.Step("load", p.step("load", nil), "transform")
+	w.Step("cleanup", p.step("cleanup", nil))
+	w.Step("archive", p.step("archive", nil), "cleanup")
+
+	status := w.Run(context.Background())
+	if status.Error == nil {
+		t.Fatal("The workflow should fail")
+	}
+
+	// Steps after the failure are skipped, but the other branch continues
+	if !status.Steps[1].Skipped || !status.Steps[2].Skipped {
+		t.Error("Steps after a failed step should be skipped")
+	}
+	if status.Steps[3].Skipped || status.Steps[4].Skipped {
+		t.Error("Steps that do not depend on a failed step should run")
+	}
+	if !strings.HasPrefix(status.String(), "workflow nightly: ERROR: failed steps: extract (2 ok, 1 failed, 2 skipped)") {
+		t.Errorf("Unexpected workflow status: %s", status)
+	}
+}
+
+func TestWorkflow_Validate(t *testing.T) {
+	noop := func() error { return nil }
+
+	w := NewWorkflow("cycle")
+	w.Step("a", noop, "c")
+	w.Step("b", noop, "a")
+	w.Step("c", noop, "b")
+	w.Step("d", noop)
+	err := w.Validate()
+	if err == nil {
+		t.Fatal("A workflow with a cycle should be invalid")
+	}
+	expectString(t, err.Error(), "schedule: workflow cycle has a cycle: a -> c -> b -> a")
+	if status := w.Run(context.Background()); status.Error == nil {
+		t.Error("Running an invalid workflow should fail")
+	}
+
+	w = NewWorkflow("unknown")
+	w.Step("a", noop, "missing")
+	if err := w.Validate(); err == nil {
+		t.Error("A workflow that depends on an unknown step should be invalid")
+	}
+
+	w = NewWorkflow("duplicate")
+	w.Step("a", noop)
+	w.Step("a", noop)
+	if err := w.Validate(); err == nil {
+		t.Error("A workflow with duplicate steps should be invalid")
+	}
+
+	w = NewWorkflow("self")
+	w.Step("a", noop, "a")
+	if err := w.Validate(); err == nil {
+		t.Error("A step that depends on itself should be invalid")
+	}
+}
+
+func TestScheduler_Workflow(t *testing.T) {
+	s := New()
+	logger := &workflowLogger{}
+	s.SetLogger(logger)
+
+	p := &pipeline{}
+	w := NewWorkflow("nightly")
+	w.Step("extract", p.step("extract", nil))
+	w.Step("transform", p.step("transform", nil), "extract")
+
+	j, err := s.Workflow(w, Never, Immediately())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectString(t, j.Name, "nightly")
+	s.WaitForJobsToFinish()
+
+	expectInt(t, len(logger.runs), 1)
+	expectString(t, logger.runs[0].Name, "nightly")
+	expectString(t, p.ran(), "extract,transform")
+
+	// Invalid workflows are not scheduled
+	invalid := NewWorkflow("invalid")
+	invalid.Step("a", func() error { return nil }, "a")
+	if _, err := s.Workflow(invalid, Never); err == nil {
+		t.Error("An invalid workflow should not be scheduled")
+	}
+}